@@ -3,9 +3,11 @@ module github.com/kislerdm/pulumi-neon
 go 1.23.1
 
 require (
+	github.com/blang/semver v3.5.1+incompatible
 	github.com/jackc/pgx/v5 v5.7.1
 	github.com/kislerdm/neon-sdk-go v0.11.0
 	github.com/pulumi/pulumi-go-provider v0.23.0
+	github.com/pulumi/pulumi-java/pkg v0.20.0
 	github.com/pulumi/pulumi/pkg/v3 v3.140.0
 	github.com/pulumi/pulumi/sdk/v3 v3.140.0
 	github.com/stretchr/testify v1.9.0
@@ -48,7 +50,6 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/sts v1.28.6 // indirect
 	github.com/aws/smithy-go v1.20.2 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-	github.com/blang/semver v3.5.1+incompatible // indirect
 	github.com/cenkalti/backoff/v3 v3.2.2 // indirect
 	github.com/charmbracelet/bubbles v0.20.0 // indirect
 	github.com/charmbracelet/bubbletea v1.2.2 // indirect
@@ -133,7 +134,6 @@ require (
 	github.com/pulumi/appdash v0.0.0-20231130102222-75f619a67231 // indirect
 	github.com/pulumi/esc v0.11.1 // indirect
 	github.com/pulumi/inflector v0.1.1 // indirect
-	github.com/pulumi/pulumi-java/pkg v0.20.0 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/ryanuber/go-glob v1.0.0 // indirect
@@ -0,0 +1,153 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+// defaultAPIEndpoint the Neon API base URL.
+const defaultAPIEndpoint = "https://console.neon.tech/api/v2"
+
+// apiClient calls the Neon API endpoints which are not covered by the SDK yet.
+type apiClient struct {
+	key        string
+	baseURL    string
+	httpClient sdk.HTTPClient
+}
+
+func newAPIClient(ctx context.Context) (*apiClient, error) {
+	cfg := infer.GetConfig[*Config](ctx)
+	if cfg.APIKey == "" {
+		return nil, errors.New("could not init Neon Client: authorization key must be provided")
+	}
+
+	endpoint, err := newAPIEndpoint(cfg.APIEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not init Neon Client: %w", err)
+	}
+
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not init Neon Client: %w", err)
+	}
+
+	return &apiClient{
+		key:        cfg.APIKey,
+		baseURL:    endpoint,
+		httpClient: httpClient,
+	}, nil
+}
+
+// newAPIEndpoint validates the Neon API base URL, the default endpoint is used if the URL is not set.
+func newAPIEndpoint(v string) (string, error) {
+	if v == "" {
+		return defaultAPIEndpoint, nil
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("api_endpoint %q is not a valid URL, expected the format https://host/path", v)
+	}
+
+	return strings.TrimSuffix(v, "/"), nil
+}
+
+// endpointHTTPClient routes the requests sent to the default Neon API endpoint to the configured endpoint.
+type endpointHTTPClient struct {
+	endpoint string
+	c        sdk.HTTPClient
+}
+
+func (c endpointHTTPClient) Do(r *http.Request) (*http.Response, error) {
+	if path, ok := strings.CutPrefix(r.URL.String(), defaultAPIEndpoint); ok {
+		u, err := url.Parse(c.endpoint + path)
+		if err != nil {
+			return nil, err
+		}
+		r.URL = u
+		r.Host = u.Host
+	}
+
+	return c.c.Do(r)
+}
+
+// apiError the Neon API error.
+type apiError struct {
+	HTTPCode int
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func (e apiError) Error() string {
+	return "[HTTP Code: " + strconv.Itoa(e.HTTPCode) + "][Error Code: " + e.Code + "] " + e.Message
+}
+
+// do sends the request to the path relative to the base URL, and decodes the response to respPayload.
+func (c apiClient) do(method, path string, reqPayload, respPayload any) error {
+	var body io.Reader
+	if reqPayload != nil {
+		b, err := json.Marshal(reqPayload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.key)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode > 299 {
+		e := apiError{HTTPCode: resp.StatusCode}
+		if err := json.Unmarshal(buf, &e); err != nil {
+			e.Message = string(buf)
+		}
+		return e
+	}
+
+	if respPayload != nil && len(buf) > 0 {
+		return json.Unmarshal(buf, respPayload)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_apiClient_do(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer foo", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/organizations/org-bar/api_keys":
+			b, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"key_name":"baz"}`, string(b))
+			_, _ = w.Write([]byte(`{"id":1,"key":"qux","name":"baz","created_at":"2024-11-01T00:00:00Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"","message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := apiClient{key: "foo", baseURL: srv.URL, httpClient: srv.Client()}
+
+	t.Run("successful request", func(t *testing.T) {
+		var got orgAPIKey
+		err := c.do(http.MethodPost, orgAPIKeysPath("org-bar"), map[string]string{"key_name": "baz"}, &got)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), got.ID)
+		assert.Equal(t, "qux", got.Key)
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		err := c.do(http.MethodGet, "/foo", nil, nil)
+		assert.EqualError(t, err, "[HTTP Code: 404][Error Code: ] not found")
+		assert.True(t, isNotFound(err))
+	})
+}
+
+func Test_newAPIEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default endpoint",
+			v:    "",
+			want: defaultAPIEndpoint,
+		},
+		{
+			name: "custom endpoint with the trailing slash",
+			v:    "https://neon.gateway.internal/api/v2/",
+			want: "https://neon.gateway.internal/api/v2",
+		},
+		{
+			name:    "invalid endpoint",
+			v:       "neon.gateway.internal",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newAPIEndpoint(tt.v)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_endpointHTTPClient_Do(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/projects/foo", r.URL.Path)
+		assert.Equal(t, "limit=1", r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := endpointHTTPClient{endpoint: srv.URL + "/api/v2", c: srv.Client()}
+
+	req, err := http.NewRequest(http.MethodGet, defaultAPIEndpoint+"/projects/foo?limit=1", nil)
+	assert.NoError(t, err)
+
+	resp, err := c.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,212 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type ApiKey struct{}
+
+type ApiKeyArgs struct {
+	KeyName string  `pulumi:"key_name"`
+	OrgID   *string `pulumi:"org_id,optional"`
+}
+
+func (k *ApiKeyArgs) Annotate(a infer.Annotator) {
+	a.Describe(&k.KeyName, "API key name.")
+	a.Describe(&k.OrgID, "Neon Org ID. The personal API key is created if not set.")
+}
+
+type ApiKeyState struct {
+	ApiKeyArgs
+	ID        string `pulumi:"identifier"`
+	Key       string `pulumi:"key" provider:"secret"`
+	CreatedAt string `pulumi:"created_at"`
+}
+
+func (k *ApiKeyState) Annotate(a infer.Annotator) {
+	a.Describe(&k.ID, "API key ID.")
+	a.Describe(&k.Key, "API key token. It is only returned when the key is created.")
+	a.Describe(&k.CreatedAt, "Timestamp when the API key was created.")
+}
+
+// orgAPIKey the organization's API key as returned by the Neon API.
+type orgAPIKey struct {
+	ID        int64     `json:"id"`
+	Key       string    `json:"key,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func orgAPIKeysPath(orgID string) string {
+	return "/organizations/" + orgID + "/api_keys"
+}
+
+func (k ApiKey) Create(ctx context.Context, _ string, inputs ApiKeyArgs, preview bool) (
+	id string, output ApiKeyState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		output.ApiKeyArgs = inputs
+
+		var resp orgAPIKey
+		if inputs.OrgID == nil {
+			var respPersonal sdk.ApiKeyCreateResponse
+			respPersonal, err = c.CreateApiKey(sdk.ApiKeyCreateRequest{KeyName: inputs.KeyName})
+			resp = orgAPIKey{
+				ID:        respPersonal.ID,
+				Key:       respPersonal.Key,
+				Name:      respPersonal.Name,
+				CreatedAt: respPersonal.CreatedAt,
+			}
+		} else {
+			var cOrg *apiClient
+			cOrg, err = newAPIClient(ctx)
+			if err == nil {
+				err = cOrg.do(http.MethodPost, orgAPIKeysPath(*inputs.OrgID),
+					sdk.ApiKeyCreateRequest{KeyName: inputs.KeyName}, &resp)
+			}
+		}
+
+		if err != nil {
+			return id, output, err
+		}
+
+		id = strconv.FormatInt(resp.ID, 10)
+		output.ID = id
+		output.Key = resp.Key
+		output.KeyName = resp.Name
+		output.CreatedAt = resp.CreatedAt.Format(time.RFC3339)
+	}
+
+	return id, output, err
+}
+
+func (k ApiKey) Read(ctx context.Context, id string, inputs ApiKeyArgs, state ApiKeyState) (
+	canonicalID string, normalizedInputs ApiKeyArgs, normalizedState ApiKeyState, err error) {
+	keyID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", inputs, state, fmt.Errorf("API key ID %s is invalid: %w", id, err)
+	}
+
+	orgID := state.OrgID
+	if orgID == nil {
+		orgID = inputs.OrgID
+	}
+
+	var keys []orgAPIKey
+	if orgID == nil {
+		var c *sdk.Client
+		c, err = NewSDKClient(ctx)
+		if err != nil {
+			return "", inputs, state, err
+		}
+
+		var resp []sdk.ApiKeysListResponseItem
+		resp, err = c.ListApiKeys()
+		for _, key := range resp {
+			keys = append(keys, orgAPIKey{ID: key.ID, Name: key.Name, CreatedAt: key.CreatedAt})
+		}
+	} else {
+		var c *apiClient
+		c, err = newAPIClient(ctx)
+		if err == nil {
+			err = c.do(http.MethodGet, orgAPIKeysPath(*orgID), nil, &keys)
+		}
+	}
+
+	if err != nil {
+		return "", inputs, state, err
+	}
+
+	// the revoked keys are not listed
+	for _, key := range keys {
+		if key.ID == keyID {
+			canonicalID = id
+			normalizedState = state
+			normalizedState.ID = id
+			normalizedState.KeyName = key.Name
+			normalizedState.OrgID = orgID
+			normalizedState.CreatedAt = key.CreatedAt.Format(time.RFC3339)
+			normalizedInputs = normalizedState.ApiKeyArgs
+			break
+		}
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (k ApiKey) Delete(ctx context.Context, id string, props ApiKeyState) error {
+	keyID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("API key ID %s is invalid: %w", id, err)
+	}
+
+	if props.OrgID == nil {
+		var c *sdk.Client
+		c, err = NewSDKClient(ctx)
+		if err == nil {
+			_, err = c.RevokeApiKey(keyID)
+		}
+	} else {
+		var c *apiClient
+		c, err = newAPIClient(ctx)
+		if err == nil {
+			err = c.do(http.MethodDelete, orgAPIKeysPath(*props.OrgID)+"/"+id, nil, nil)
+		}
+	}
+
+	if isNotFound(err) {
+		err = nil
+	}
+	return err
+}
+
+func (k ApiKey) Diff(_ context.Context, _ string, olds ApiKeyState, news ApiKeyArgs) (p.DiffResponse, error) {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the API keys are immutable, the new key is issued before the old one is revoked
+	if news.KeyName != olds.KeyName {
+		o.HasChanges = true
+		o.DetailedDiff["key_name"] = p.PropertyDiff{
+			Kind:      p.UpdateReplace,
+			InputDiff: true,
+		}
+	}
+
+	if !reflect.DeepEqual(news.OrgID, olds.OrgID) {
+		o.HasChanges = true
+		o.DetailedDiff["org_id"] = p.PropertyDiff{
+			Kind:      p.UpdateReplace,
+			InputDiff: true,
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKey_Diff(t *testing.T) {
+	org, otherOrg := "org-foo", "org-bar"
+
+	tests := []struct {
+		name string
+		olds ApiKeyState
+		news ApiKeyArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			olds: ApiKeyState{ApiKeyArgs: ApiKeyArgs{KeyName: "foo", OrgID: &org}},
+			news: ApiKeyArgs{KeyName: "foo", OrgID: &org},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "key name changed",
+			olds: ApiKeyState{ApiKeyArgs: ApiKeyArgs{KeyName: "foo"}},
+			news: ApiKeyArgs{KeyName: "bar"},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"key_name": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "org changed",
+			olds: ApiKeyState{ApiKeyArgs: ApiKeyArgs{KeyName: "foo", OrgID: &org}},
+			news: ApiKeyArgs{KeyName: "foo", OrgID: &otherOrg},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"org_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "personal key moved to the org",
+			olds: ApiKeyState{ApiKeyArgs: ApiKeyArgs{KeyName: "foo"}},
+			news: ApiKeyArgs{KeyName: "foo", OrgID: &org},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"org_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApiKey{}.Diff(context.Background(), "1", tt.olds, tt.news)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApiKey_Read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api_keys", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":1,"name":"foo","created_at":"2024-01-01T00:00:00Z"}]`))
+	})
+	mux.HandleFunc("GET /organizations/org-foo/api_keys", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":2,"name":"bar","created_at":"2024-01-01T00:00:00Z"}]`))
+	})
+	s := newTestServer(t, mux)
+
+	tests := []struct {
+		name     string
+		id       string
+		state    resource.PropertyMap
+		wantID   string
+		wantName string
+	}{
+		{
+			name:     "personal key",
+			id:       "1",
+			state:    resource.PropertyMap{"key_name": resource.NewStringProperty("foo")},
+			wantID:   "1",
+			wantName: "foo",
+		},
+		{
+			name:   "personal key revoked",
+			id:     "3",
+			state:  resource.PropertyMap{"key_name": resource.NewStringProperty("foo")},
+			wantID: "",
+		},
+		{
+			name: "org key",
+			id:   "2",
+			state: resource.PropertyMap{
+				"key_name": resource.NewStringProperty("bar"),
+				"org_id":   resource.NewStringProperty("org-foo"),
+			},
+			wantID:   "2",
+			wantName: "bar",
+		},
+		{
+			name: "org key revoked",
+			id:   "3",
+			state: resource.PropertyMap{
+				"key_name": resource.NewStringProperty("bar"),
+				"org_id":   resource.NewStringProperty("org-foo"),
+			},
+			wantID: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Read(p.ReadRequest{
+				ID:         tt.id,
+				Urn:        newTestURN("ApiKey"),
+				Properties: tt.state,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.ID)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantName, got.Properties["key_name"].StringValue())
+			}
+		})
+	}
+}
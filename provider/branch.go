@@ -0,0 +1,282 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type Branch struct{}
+
+type BranchArgs struct {
+	ProjectID       string  `pulumi:"project_id"`
+	Name            *string `pulumi:"name,optional"`
+	ParentID        *string `pulumi:"parent_id,optional"`
+	ParentName      *string `pulumi:"parent_name,optional"`
+	ParentLsn       *string `pulumi:"parent_lsn,optional"`
+	ParentTimestamp *string `pulumi:"parent_timestamp,optional"`
+	Protected       *bool   `pulumi:"protected,optional"`
+}
+
+func (br *BranchArgs) Annotate(a infer.Annotator) {
+	a.Describe(&br.ProjectID, "Neon project ID.")
+	a.Describe(&br.Name, "Branch name.")
+	a.Describe(&br.ParentID,
+		"ID of the parent branch. The project's default branch is used if neither parent_id, nor parent_name is set.")
+	a.Describe(&br.ParentName, "Name of the parent branch. It is ignored if parent_id is set.")
+	a.Describe(&br.ParentLsn, "Log Sequence Number (LSN) on the parent branch to branch from.")
+	a.Describe(&br.ParentTimestamp,
+		"Point in time on the parent branch to branch from. The timestamp must be in the RFC3339 format, "+
+			"e.g. 2024-02-26T12:00:00Z.")
+	a.Describe(&br.Protected, "Whether the branch is protected.")
+}
+
+type BranchState struct {
+	BranchArgs
+	ID             string `pulumi:"identifier"`
+	ParentBranchID string `pulumi:"parent_branch_id"`
+	LogicalSize    int64  `pulumi:"logical_size"`
+	CurrentState   string `pulumi:"current_state"`
+	Default        bool   `pulumi:"default"`
+}
+
+func (br *BranchState) Annotate(a infer.Annotator) {
+	a.Describe(&br.ID, "Branch ID.")
+	a.Describe(&br.ParentBranchID, "ID of the parent branch.")
+	a.Describe(&br.LogicalSize, "Logical size of the branch, in bytes.")
+	a.Describe(&br.CurrentState, "Current state of the branch.")
+	a.Describe(&br.Default, "Whether the branch is the project's default branch.")
+}
+
+func (br Branch) Create(ctx context.Context, _ string, inputs BranchArgs, preview bool) (
+	id string, output BranchState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		req := sdk.BranchCreateRequestBranch{
+			Name:      inputs.Name,
+			ParentID:  inputs.ParentID,
+			ParentLsn: inputs.ParentLsn,
+			Protected: inputs.Protected,
+		}
+
+		if req.ParentID == nil && inputs.ParentName != nil {
+			var parentID string
+			parentID, err = findBranchIDByName(c, inputs.ProjectID, *inputs.ParentName)
+			if err != nil {
+				return id, output, err
+			}
+			req.ParentID = &parentID
+		}
+
+		if inputs.ParentTimestamp != nil {
+			var ts time.Time
+			ts, err = time.Parse(time.RFC3339, *inputs.ParentTimestamp)
+			if err != nil {
+				return id, output, fmt.Errorf("could not parse parent_timestamp: %w", err)
+			}
+			req.ParentTimestamp = &ts
+		}
+
+		var resp sdk.CreatedBranch
+		resp, err = c.CreateProjectBranch(inputs.ProjectID, &sdk.CreateProjectBranchReqObj{
+			BranchCreateRequest: sdk.BranchCreateRequest{Branch: &req},
+		})
+		if err != nil {
+			return id, output, err
+		}
+
+		id = resp.BranchResponse.Branch.ID
+		output = newBranchState(inputs, resp.BranchResponse.Branch)
+		err = newResourceInitFailedError(waitForOperations(ctx, c, resp.Operations))
+	}
+
+	return id, output, err
+}
+
+func findBranchIDByName(c *sdk.Client, projectID, name string) (string, error) {
+	resp, err := c.ListProjectBranches(projectID, &name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, br := range resp.BranchesResponse.Branches {
+		if br.Name == name {
+			return br.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("branch %s not found in the project %s", name, projectID)
+}
+
+func newBranchState(inputs BranchArgs, br sdk.Branch) BranchState {
+	o := BranchState{
+		BranchArgs:   inputs,
+		ID:           br.ID,
+		CurrentState: string(br.CurrentState),
+		Default:      br.Default,
+	}
+
+	o.ProjectID = br.ProjectID
+	o.Name = &br.Name
+	o.Protected = &br.Protected
+
+	if br.ParentID != nil {
+		o.ParentBranchID = *br.ParentID
+	}
+
+	if br.LogicalSize != nil {
+		o.LogicalSize = *br.LogicalSize
+	}
+
+	return o
+}
+
+func (br Branch) Update(ctx context.Context, id string, olds BranchState, news BranchArgs, preview bool) (
+	output BranchState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output = olds
+	output.BranchArgs = news
+	if !preview {
+		var resp sdk.BranchOperations
+		resp, err = c.UpdateProjectBranch(news.ProjectID, id, sdk.BranchUpdateRequest{
+			Branch: sdk.BranchUpdateRequestBranch{
+				Name:      news.Name,
+				Protected: news.Protected,
+			},
+		})
+		if err == nil {
+			output = newBranchState(news, resp.BranchResponse.Branch)
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+
+	return output, err
+}
+
+func (br Branch) Read(ctx context.Context, id string, inputs BranchArgs, state BranchState) (
+	canonicalID string, normalizedInputs BranchArgs, normalizedState BranchState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		projectID := state.ProjectID
+		if projectID == "" {
+			projectID = inputs.ProjectID
+		}
+
+		var resp sdk.GetProjectBranchRespObj
+		resp, err = c.GetProjectBranch(projectID, id)
+		switch {
+		case isNotFound(err):
+			// the branch was deleted outside of pulumi
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		canonicalID = resp.BranchResponse.Branch.ID
+		normalizedState = newBranchState(state.BranchArgs, resp.BranchResponse.Branch)
+		normalizedInputs = normalizedState.BranchArgs
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (br Branch) Delete(ctx context.Context, id string, props BranchState) error {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var resp sdk.BranchOperations
+		resp, err = c.DeleteProjectBranch(props.ProjectID, id)
+		switch {
+		case isNotFound(err):
+			err = nil
+		case err == nil:
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+	return err
+}
+
+func (br Branch) Diff(_ context.Context, _ string, olds BranchState, news BranchArgs) (p.DiffResponse, error) {
+	return branchInputChange(olds.BranchArgs, news), nil
+}
+
+func branchInputChange(olds BranchArgs, news BranchArgs) p.DiffResponse {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the branch's name and protection can be changed in place
+	if news.Name != nil && !reflect.DeepEqual(news.Name, olds.Name) {
+		o.HasChanges = true
+		o.DetailedDiff["name"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	if news.Protected != nil && !reflect.DeepEqual(news.Protected, olds.Protected) {
+		o.HasChanges = true
+		o.DetailedDiff["protected"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	// the branch must be re-created if its lineage changes
+	if news.ProjectID != olds.ProjectID {
+		o.HasChanges = true
+		o.DeleteBeforeReplace = true
+		o.DetailedDiff["project_id"] = p.PropertyDiff{
+			Kind:      p.UpdateReplace,
+			InputDiff: true,
+		}
+	}
+
+	lineage := map[string][2]*string{
+		"parent_id":        {olds.ParentID, news.ParentID},
+		"parent_lsn":       {olds.ParentLsn, news.ParentLsn},
+		"parent_timestamp": {olds.ParentTimestamp, news.ParentTimestamp},
+	}
+
+	// the parent name is ignored if the parent id is set
+	if news.ParentID == nil || *news.ParentID == "" {
+		lineage["parent_name"] = [2]*string{olds.ParentName, news.ParentName}
+	}
+
+	for k, v := range lineage {
+		if !reflect.DeepEqual(v[0], v[1]) {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	return o
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_branchInputChange(t *testing.T) {
+	name := "foo"
+	newName := "bar"
+	lsn := "0/1E25DE0"
+	parentID := "br-foo"
+	protected := true
+
+	tests := []struct {
+		name string
+		olds BranchArgs
+		news BranchArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			olds: BranchArgs{ProjectID: "p", Name: &name},
+			news: BranchArgs{ProjectID: "p", Name: &name},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "name removed from the manifest",
+			olds: BranchArgs{ProjectID: "p", Name: &name},
+			news: BranchArgs{ProjectID: "p"},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "name and protection changed in place",
+			olds: BranchArgs{ProjectID: "p", Name: &name},
+			news: BranchArgs{ProjectID: "p", Name: &newName, Protected: &protected},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"name":      {Kind: p.Update, InputDiff: true},
+					"protected": {Kind: p.Update, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "parent lsn changed",
+			olds: BranchArgs{ProjectID: "p"},
+			news: BranchArgs{ProjectID: "p", ParentLsn: &lsn},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"parent_lsn": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "parent name changed while the parent id takes precedence",
+			olds: BranchArgs{ProjectID: "p", ParentID: &parentID, ParentName: &name},
+			news: BranchArgs{ProjectID: "p", ParentID: &parentID, ParentName: &newName},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "parent name changed",
+			olds: BranchArgs{ProjectID: "p", ParentName: &name},
+			news: BranchArgs{ProjectID: "p", ParentName: &newName},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"parent_name": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "project changed",
+			olds: BranchArgs{ProjectID: "p"},
+			news: BranchArgs{ProjectID: "q"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"project_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, branchInputChange(tt.olds, tt.news))
+		})
+	}
+}
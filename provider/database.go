@@ -0,0 +1,213 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type Database struct{}
+
+type DatabaseArgs struct {
+	ProjectID string `pulumi:"project_id"`
+	BranchID  string `pulumi:"branch_id"`
+	Name      string `pulumi:"name"`
+	OwnerName string `pulumi:"owner_name"`
+}
+
+func (db *DatabaseArgs) Annotate(a infer.Annotator) {
+	a.Describe(&db.ProjectID, "Neon project ID.")
+	a.Describe(&db.BranchID, "ID of the branch the database belongs to.")
+	a.Describe(&db.Name, "Database name.")
+	a.Describe(&db.OwnerName, "Name of the role which owns the database.")
+}
+
+type DatabaseState struct {
+	DatabaseArgs
+	ID string `pulumi:"identifier"`
+}
+
+func (db *DatabaseState) Annotate(a infer.Annotator) {
+	a.Describe(&db.ID, "Database identifier in the format project_id/branch_id/name. "+
+		"It keeps the name the database had when it was created, or imported, and it does not change "+
+		"when the database is renamed in place.")
+}
+
+func (db Database) Create(ctx context.Context, _ string, inputs DatabaseArgs, preview bool) (
+	id string, output DatabaseState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		var resp sdk.DatabaseOperations
+		resp, err = c.CreateProjectBranchDatabase(inputs.ProjectID, inputs.BranchID, sdk.DatabaseCreateRequest{
+			Database: sdk.DatabaseCreateRequestDatabase{
+				Name:      inputs.Name,
+				OwnerName: inputs.OwnerName,
+			},
+		})
+		if err != nil {
+			return id, output, err
+		}
+
+		output = newDatabaseState(inputs.ProjectID, resp.DatabaseResponse.Database)
+		id = output.ID
+		err = newResourceInitFailedError(waitForOperations(ctx, c, resp.Operations))
+	}
+
+	return id, output, err
+}
+
+func newDatabaseState(projectID string, db sdk.Database) DatabaseState {
+	return DatabaseState{
+		DatabaseArgs: DatabaseArgs{
+			ProjectID: projectID,
+			BranchID:  db.BranchID,
+			Name:      db.Name,
+			OwnerName: db.OwnerName,
+		},
+		ID: newCompositeID(projectID, db.BranchID, db.Name),
+	}
+}
+
+func (db Database) Update(ctx context.Context, _ string, olds DatabaseState, news DatabaseArgs, preview bool) (
+	output DatabaseState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output = olds
+	output.DatabaseArgs = news
+	if !preview {
+		req := sdk.DatabaseUpdateRequestDatabase{}
+		if news.Name != olds.Name {
+			req.Name = &news.Name
+		}
+		if news.OwnerName != olds.OwnerName {
+			req.OwnerName = &news.OwnerName
+		}
+
+		var resp sdk.DatabaseOperations
+		resp, err = c.UpdateProjectBranchDatabase(olds.ProjectID, olds.BranchID, olds.Name,
+			sdk.DatabaseUpdateRequest{Database: req})
+		if err == nil {
+			output = newDatabaseState(olds.ProjectID, resp.DatabaseResponse.Database)
+			// the identifier matches the resource ID which does not change when the database is renamed
+			output.ID = olds.ID
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+
+	return output, err
+}
+
+func (db Database) Read(ctx context.Context, id string, inputs DatabaseArgs, state DatabaseState) (
+	canonicalID string, normalizedInputs DatabaseArgs, normalizedState DatabaseState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		// the database could have been renamed in place, hence the state takes precedence over the ID
+		projectID, branchID, name := state.ProjectID, state.BranchID, state.Name
+		if projectID == "" || branchID == "" || name == "" {
+			var ids []string
+			ids, err = parseCompositeID(id, 3)
+			if err != nil {
+				return "", inputs, state, err
+			}
+			projectID, branchID, name = ids[0], ids[1], ids[2]
+		}
+
+		var resp sdk.DatabaseResponse
+		resp, err = c.GetProjectBranchDatabase(projectID, branchID, name)
+		switch {
+		case isNotFound(err):
+			// the database was deleted outside of pulumi
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		normalizedState = newDatabaseState(projectID, resp.Database)
+		normalizedInputs = normalizedState.DatabaseArgs
+		canonicalID = id
+		if state.ID == "" {
+			canonicalID = normalizedState.ID
+		}
+		normalizedState.ID = canonicalID
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (db Database) Delete(ctx context.Context, _ string, props DatabaseState) error {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var resp sdk.DatabaseOperations
+		resp, err = c.DeleteProjectBranchDatabase(props.ProjectID, props.BranchID, props.Name)
+		switch {
+		case isNotFound(err):
+			err = nil
+		case err == nil:
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+	return err
+}
+
+func (db Database) Diff(_ context.Context, _ string, olds DatabaseState, news DatabaseArgs) (p.DiffResponse, error) {
+	return databaseInputChange(olds.DatabaseArgs, news), nil
+}
+
+func databaseInputChange(olds DatabaseArgs, news DatabaseArgs) p.DiffResponse {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the database cannot be moved to another branch
+	for k, changed := range map[string]bool{
+		"project_id": news.ProjectID != olds.ProjectID,
+		"branch_id":  news.BranchID != olds.BranchID,
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the database can be renamed and handed over to another owner in place
+	for k, changed := range map[string]bool{
+		"name":       news.Name != olds.Name,
+		"owner_name": news.OwnerName != olds.OwnerName,
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.Update,
+				InputDiff: true,
+			}
+		}
+	}
+
+	return o
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_databaseInputChange(t *testing.T) {
+	tests := []struct {
+		name string
+		olds DatabaseArgs
+		news DatabaseArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			olds: DatabaseArgs{ProjectID: "p", BranchID: "br", Name: "foo", OwnerName: "bar"},
+			news: DatabaseArgs{ProjectID: "p", BranchID: "br", Name: "foo", OwnerName: "bar"},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "database renamed and handed over in place",
+			olds: DatabaseArgs{ProjectID: "p", BranchID: "br", Name: "foo", OwnerName: "bar"},
+			news: DatabaseArgs{ProjectID: "p", BranchID: "br", Name: "baz", OwnerName: "qux"},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"name":       {Kind: p.Update, InputDiff: true},
+					"owner_name": {Kind: p.Update, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "branch changed",
+			olds: DatabaseArgs{ProjectID: "p", BranchID: "br", Name: "foo", OwnerName: "bar"},
+			news: DatabaseArgs{ProjectID: "p", BranchID: "br-new", Name: "foo", OwnerName: "bar"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"branch_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "project changed",
+			olds: DatabaseArgs{ProjectID: "p", BranchID: "br", Name: "foo", OwnerName: "bar"},
+			news: DatabaseArgs{ProjectID: "q", BranchID: "br", Name: "foo", OwnerName: "bar"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"project_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, databaseInputChange(tt.olds, tt.news))
+		})
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+type Endpoint struct{}
+
+type EndpointArgs struct {
+	ProjectID             string            `pulumi:"project_id"`
+	BranchID              string            `pulumi:"branch_id"`
+	Type                  *EndpointType     `pulumi:"type,optional"`
+	AutoscalingLimitMinCu *float64          `pulumi:"autoscaling_limit_min_cu,optional"`
+	AutoscalingLimitMaxCu *float64          `pulumi:"autoscaling_limit_max_cu,optional"`
+	SuspendTimeoutSeconds *int              `pulumi:"suspend_timeout_seconds,optional"`
+	PoolerEnabled         *bool             `pulumi:"pooler_enabled,optional"`
+	PoolerMode            *string           `pulumi:"pooler_mode,optional"`
+	Provisioner           *Provisioner      `pulumi:"provisioner,optional"`
+	PgSettings            map[string]string `pulumi:"pg_settings,optional"`
+}
+
+func (ep *EndpointArgs) Annotate(a infer.Annotator) {
+	a.Describe(&ep.ProjectID, "Neon project ID.")
+	a.Describe(&ep.BranchID, "ID of the branch the endpoint is associated with.")
+	a.Describe(&ep.Type, "Endpoint type.")
+	a.SetDefault(&ep.Type, EndpointType(sdk.EndpointTypeReadWrite))
+	a.Describe(&ep.AutoscalingLimitMinCu, "Minimum number of Compute Units.")
+	a.Describe(&ep.AutoscalingLimitMaxCu, "Maximum number of Compute Units.")
+	a.Describe(&ep.SuspendTimeoutSeconds,
+		"Duration of inactivity in seconds after which the endpoint is suspended. "+
+			"0 sets the Neon default, -1 disables the suspension.")
+	a.Describe(&ep.PoolerEnabled, "Whether to enable connection pooling for the endpoint.")
+	a.Describe(&ep.PoolerMode, "Connection pooler mode. Only the transaction mode is supported.")
+	a.Describe(&ep.Provisioner, "Compute provisioner.")
+	a.Describe(&ep.PgSettings, "Postgres settings applied to the endpoint.")
+}
+
+type EndpointState struct {
+	EndpointArgs
+	ID           string `pulumi:"identifier"`
+	Host         string `pulumi:"host"`
+	HostPooler   string `pulumi:"host_pooler"`
+	CurrentState string `pulumi:"current_state"`
+}
+
+func (ep *EndpointState) Annotate(a infer.Annotator) {
+	a.Describe(&ep.ID, "Endpoint ID.")
+	a.Describe(&ep.Host, "The endpoint's host.")
+	a.Describe(&ep.HostPooler, "The endpoint's host with the pooler mode active.")
+	a.Describe(&ep.CurrentState, "Current state of the endpoint.")
+}
+
+func (ep Endpoint) Check(ctx context.Context, _ string, _, newInputs resource.PropertyMap) (
+	EndpointArgs, []p.CheckFailure, error) {
+	inputs, failures, err := infer.DefaultCheck[EndpointArgs](ctx, newInputs)
+	if err != nil {
+		return inputs, failures, err
+	}
+
+	failures = append(failures, validateEnum("type", inputs.Type)...)
+	failures = append(failures, validateEnum("provisioner", inputs.Provisioner)...)
+	return inputs, failures, nil
+}
+
+func (ep Endpoint) Create(ctx context.Context, _ string, inputs EndpointArgs, preview bool) (
+	id string, output EndpointState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		req := sdk.EndpointCreateRequestEndpoint{
+			BranchID:      inputs.BranchID,
+			Type:          sdk.EndpointTypeReadWrite,
+			PoolerEnabled: inputs.PoolerEnabled,
+			Provisioner:   newSDKProvisioner(inputs.Provisioner),
+		}
+
+		if inputs.Type != nil {
+			req.Type = sdk.EndpointType(*inputs.Type)
+		}
+
+		req.AutoscalingLimitMinCu, req.AutoscalingLimitMaxCu = newComputeUnits(
+			inputs.AutoscalingLimitMinCu, inputs.AutoscalingLimitMaxCu)
+		req.SuspendTimeoutSeconds = newSuspendTimeoutSeconds(inputs.SuspendTimeoutSeconds)
+
+		if inputs.PoolerMode != nil {
+			v := sdk.EndpointPoolerMode(*inputs.PoolerMode)
+			req.PoolerMode = &v
+		}
+
+		req.Settings = newEndpointSettings(inputs.PgSettings)
+
+		var resp sdk.EndpointOperations
+		resp, err = c.CreateProjectEndpoint(inputs.ProjectID, sdk.EndpointCreateRequest{Endpoint: req})
+		if err != nil {
+			return id, output, err
+		}
+
+		id = resp.EndpointResponse.Endpoint.ID
+		output = newEndpointState(inputs, resp.EndpointResponse.Endpoint)
+		err = newResourceInitFailedError(waitForOperations(ctx, c, resp.Operations))
+	}
+
+	return id, output, err
+}
+
+func newComputeUnits(minCu, maxCu *float64) (*sdk.ComputeUnit, *sdk.ComputeUnit) {
+	var o [2]*sdk.ComputeUnit
+	for i, v := range []*float64{minCu, maxCu} {
+		if v != nil {
+			cu := sdk.ComputeUnit(*v)
+			o[i] = &cu
+		}
+	}
+	return o[0], o[1]
+}
+
+func newSuspendTimeoutSeconds(v *int) *sdk.SuspendTimeoutSeconds {
+	if v == nil {
+		return nil
+	}
+	o := sdk.SuspendTimeoutSeconds(*v)
+	return &o
+}
+
+func newEndpointSettings(pgSettings map[string]string) *sdk.EndpointSettingsData {
+	if pgSettings == nil {
+		return nil
+	}
+
+	v := make(sdk.PgSettingsData, len(pgSettings))
+	for k, setting := range pgSettings {
+		v[k] = setting
+	}
+
+	return &sdk.EndpointSettingsData{PgSettings: &v}
+}
+
+func newSettingsMap(v map[string]interface{}) map[string]string {
+	if len(v) == 0 {
+		return nil
+	}
+
+	o := make(map[string]string, len(v))
+	for k, setting := range v {
+		o[k] = fmt.Sprint(setting)
+	}
+	return o
+}
+
+func newEndpointState(inputs EndpointArgs, ep sdk.Endpoint) EndpointState {
+	o := EndpointState{
+		EndpointArgs: inputs,
+		ID:           ep.ID,
+		Host:         ep.Host,
+		HostPooler:   newHostPooler(ep.Host),
+		CurrentState: string(ep.CurrentState),
+	}
+
+	o.ProjectID = ep.ProjectID
+	o.BranchID = ep.BranchID
+
+	o.Type = newEndpointType(ep.Type)
+
+	minCu := float64(ep.AutoscalingLimitMinCu)
+	maxCu := float64(ep.AutoscalingLimitMaxCu)
+	o.AutoscalingLimitMinCu = &minCu
+	o.AutoscalingLimitMaxCu = &maxCu
+
+	suspendTimeout := int(ep.SuspendTimeoutSeconds)
+	o.SuspendTimeoutSeconds = &suspendTimeout
+
+	o.PoolerEnabled = &ep.PoolerEnabled
+	if ep.PoolerMode != "" {
+		poolerMode := string(ep.PoolerMode)
+		o.PoolerMode = &poolerMode
+	}
+
+	o.Provisioner = newProvisioner(ep.Provisioner)
+
+	if ep.Settings.PgSettings != nil {
+		o.PgSettings = newSettingsMap(*ep.Settings.PgSettings)
+	}
+
+	return o
+}
+
+func (ep Endpoint) Update(ctx context.Context, id string, olds EndpointState, news EndpointArgs, preview bool) (
+	output EndpointState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output = olds
+	output.EndpointArgs = news
+	if !preview {
+		req := sdk.EndpointUpdateRequestEndpoint{
+			PoolerEnabled:         news.PoolerEnabled,
+			Provisioner:           newSDKProvisioner(news.Provisioner),
+			SuspendTimeoutSeconds: newSuspendTimeoutSeconds(news.SuspendTimeoutSeconds),
+			Settings:              newEndpointSettings(news.PgSettings),
+		}
+
+		req.AutoscalingLimitMinCu, req.AutoscalingLimitMaxCu = newComputeUnits(
+			news.AutoscalingLimitMinCu, news.AutoscalingLimitMaxCu)
+
+		if news.PoolerMode != nil {
+			v := sdk.EndpointPoolerMode(*news.PoolerMode)
+			req.PoolerMode = &v
+		}
+
+		var resp sdk.EndpointOperations
+		resp, err = c.UpdateProjectEndpoint(news.ProjectID, id, sdk.EndpointUpdateRequest{Endpoint: req})
+		if err == nil {
+			output = newEndpointState(news, resp.EndpointResponse.Endpoint)
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+
+	return output, err
+}
+
+func (ep Endpoint) Read(ctx context.Context, id string, inputs EndpointArgs, state EndpointState) (
+	canonicalID string, normalizedInputs EndpointArgs, normalizedState EndpointState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		projectID := state.ProjectID
+		if projectID == "" {
+			projectID = inputs.ProjectID
+		}
+
+		var resp sdk.EndpointResponse
+		resp, err = c.GetProjectEndpoint(projectID, id)
+		switch {
+		case isNotFound(err):
+			// the endpoint was deleted outside of pulumi
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		canonicalID = resp.Endpoint.ID
+		normalizedState = newEndpointState(state.EndpointArgs, resp.Endpoint)
+		normalizedInputs = normalizedState.EndpointArgs
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (ep Endpoint) Delete(ctx context.Context, id string, props EndpointState) error {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var resp sdk.EndpointOperations
+		resp, err = c.DeleteProjectEndpoint(props.ProjectID, id)
+		switch {
+		case isNotFound(err):
+			err = nil
+		case err == nil:
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+	return err
+}
+
+func (ep Endpoint) Diff(_ context.Context, _ string, olds EndpointState, news EndpointArgs) (p.DiffResponse, error) {
+	return endpointInputChange(olds.EndpointArgs, news), nil
+}
+
+func endpointInputChange(olds EndpointArgs, news EndpointArgs) p.DiffResponse {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the endpoint must be re-created if moved, or if its type changes
+	if news.ProjectID != olds.ProjectID || news.BranchID != olds.BranchID || optionalChanged(olds.Type, news.Type) {
+		o.DeleteBeforeReplace = true
+	}
+
+	for k, changed := range map[string]bool{
+		"project_id": news.ProjectID != olds.ProjectID,
+		"branch_id":  news.BranchID != olds.BranchID,
+		"type":       optionalChanged(olds.Type, news.Type),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the compute settings can be changed in place,
+	// the cloud state will not be changed if the attribute was removed from the manifest
+	for k, changed := range map[string]bool{
+		"autoscaling_limit_min_cu": optionalChanged(olds.AutoscalingLimitMinCu, news.AutoscalingLimitMinCu),
+		"autoscaling_limit_max_cu": optionalChanged(olds.AutoscalingLimitMaxCu, news.AutoscalingLimitMaxCu),
+		"suspend_timeout_seconds":  optionalChanged(olds.SuspendTimeoutSeconds, news.SuspendTimeoutSeconds),
+		"pooler_enabled":           optionalChanged(olds.PoolerEnabled, news.PoolerEnabled),
+		"pooler_mode":              optionalChanged(olds.PoolerMode, news.PoolerMode),
+		"provisioner":              optionalChanged(olds.Provisioner, news.Provisioner),
+		"pg_settings":              news.PgSettings != nil && !maps.Equal(olds.PgSettings, news.PgSettings),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.Update,
+				InputDiff: true,
+			}
+		}
+	}
+
+	return o
+}
+
+// optionalChanged checks if the optional input was set to a new value.
+func optionalChanged[T comparable](olds, news *T) bool {
+	return news != nil && (olds == nil || *olds != *news)
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_endpointInputChange(t *testing.T) {
+	readWrite, readOnly := EndpointType("read_write"), EndpointType("read_only")
+	provisionerPod, provisionerVM := Provisioner("k8s-pod"), Provisioner("k8s-neonvm")
+	minCu, maxCu := 0.25, 2.0
+	suspendTimeout := 300
+	poolerEnabled := true
+
+	tests := []struct {
+		name string
+		olds EndpointArgs
+		news EndpointArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			olds: EndpointArgs{ProjectID: "p", BranchID: "br", Type: &readWrite},
+			news: EndpointArgs{ProjectID: "p", BranchID: "br", Type: &readWrite},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "optional attributes removed from the manifest",
+			olds: EndpointArgs{
+				ProjectID: "p", BranchID: "br", Type: &readWrite, AutoscalingLimitMinCu: &minCu,
+				SuspendTimeoutSeconds: &suspendTimeout, Provisioner: &provisionerPod,
+				PgSettings: map[string]string{"max_connections": "100"},
+			},
+			news: EndpointArgs{ProjectID: "p", BranchID: "br"},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "compute settings changed in place",
+			olds: EndpointArgs{
+				ProjectID: "p", BranchID: "br", AutoscalingLimitMinCu: &minCu, AutoscalingLimitMaxCu: &minCu,
+				Provisioner: &provisionerPod,
+			},
+			news: EndpointArgs{
+				ProjectID: "p", BranchID: "br", AutoscalingLimitMinCu: &minCu, AutoscalingLimitMaxCu: &maxCu,
+				SuspendTimeoutSeconds: &suspendTimeout, PoolerEnabled: &poolerEnabled, Provisioner: &provisionerVM,
+				PgSettings: map[string]string{"max_connections": "100"},
+			},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"autoscaling_limit_max_cu": {Kind: p.Update, InputDiff: true},
+					"suspend_timeout_seconds":  {Kind: p.Update, InputDiff: true},
+					"pooler_enabled":           {Kind: p.Update, InputDiff: true},
+					"provisioner":              {Kind: p.Update, InputDiff: true},
+					"pg_settings":              {Kind: p.Update, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "type changed",
+			olds: EndpointArgs{ProjectID: "p", BranchID: "br", Type: &readWrite},
+			news: EndpointArgs{ProjectID: "p", BranchID: "br", Type: &readOnly},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"type": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "branch changed",
+			olds: EndpointArgs{ProjectID: "p", BranchID: "br"},
+			news: EndpointArgs{ProjectID: "p", BranchID: "br-new"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"branch_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointInputChange(tt.olds, tt.news))
+		})
+	}
+}
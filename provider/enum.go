@@ -0,0 +1,89 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type Provisioner string
+
+func (Provisioner) Values() []infer.EnumValue[Provisioner] {
+	return []infer.EnumValue[Provisioner]{
+		{Name: "K8sPod", Value: "k8s-pod", Description: "Kubernetes pod."},
+		{
+			Name: "K8sNeonVM", Value: "k8s-neonvm",
+			Description: "Neon virtual machine which supports autoscaling.",
+		},
+	}
+}
+
+type EndpointType string
+
+func (EndpointType) Values() []infer.EnumValue[EndpointType] {
+	return []infer.EnumValue[EndpointType]{
+		{Name: "ReadWrite", Value: EndpointType(sdk.EndpointTypeReadWrite), Description: "Read-write endpoint."},
+		{Name: "ReadOnly", Value: EndpointType(sdk.EndpointTypeReadOnly), Description: "Read-only endpoint."},
+	}
+}
+
+// validateEnum checks that the value is one of the enum's allowed values.
+func validateEnum[T interface {
+	comparable
+	Values() []infer.EnumValue[T]
+}](property string, v *T) []p.CheckFailure {
+	if v == nil {
+		return nil
+	}
+
+	var allowed []string
+	for _, value := range (*v).Values() {
+		if value.Value == *v {
+			return nil
+		}
+		allowed = append(allowed, fmt.Sprint(value.Value))
+	}
+
+	return []p.CheckFailure{{
+		Property: property,
+		Reason:   fmt.Sprintf("%q is not allowed, expected one of: %s", fmt.Sprint(*v), strings.Join(allowed, ", ")),
+	}}
+}
+
+func newSDKProvisioner(v *Provisioner) *sdk.Provisioner {
+	if v == nil {
+		return nil
+	}
+	o := sdk.Provisioner(*v)
+	return &o
+}
+
+func newProvisioner(v sdk.Provisioner) *Provisioner {
+	if v == "" {
+		return nil
+	}
+	o := Provisioner(v)
+	return &o
+}
+
+func newEndpointType(v sdk.EndpointType) *EndpointType {
+	o := EndpointType(v)
+	return &o
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateEnum(t *testing.T) {
+	valid, invalid := Provisioner("k8s-neonvm"), Provisioner("k8s-foo")
+
+	assert.Nil(t, validateEnum[Provisioner]("provisioner", nil))
+	assert.Nil(t, validateEnum("provisioner", &valid))
+	assert.Equal(t, []p.CheckFailure{
+		{Property: "provisioner", Reason: `"k8s-foo" is not allowed, expected one of: k8s-pod, k8s-neonvm`},
+	}, validateEnum("provisioner", &invalid))
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_equalUnordered(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{name: "both empty", a: nil, b: []string{}, want: true},
+		{name: "same order", a: []string{"foo", "bar"}, b: []string{"foo", "bar"}, want: true},
+		{name: "different order", a: []string{"foo", "bar"}, b: []string{"bar", "foo"}, want: true},
+		{name: "different elements", a: []string{"foo", "bar"}, b: []string{"foo", "baz"}, want: false},
+		{name: "different length", a: []string{"foo"}, b: []string{"foo", "foo"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, equalUnordered(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type Jwks struct{}
+
+type JwksArgs struct {
+	ProjectID    string   `pulumi:"project_id"`
+	JwksURL      string   `pulumi:"jwks_url"`
+	ProviderName string   `pulumi:"provider_name"`
+	BranchIDs    []string `pulumi:"branch_ids,optional"`
+	RoleNames    []string `pulumi:"role_names,optional"`
+	JwtAudience  *string  `pulumi:"jwt_audience,optional"`
+}
+
+func (j *JwksArgs) Annotate(a infer.Annotator) {
+	a.Describe(&j.ProjectID, "Neon project ID.")
+	a.Describe(&j.JwksURL, "URL of the JSON Web Key Set. It must be a valid HTTPS URL.")
+	a.Describe(&j.ProviderName, "Name of the authentication provider, e.g. Clerk, Stytch, Auth0.")
+	a.Describe(&j.BranchIDs, "IDs of the branches the JWKS is accepted on. It is accepted on all branches if not set.")
+	a.Describe(&j.RoleNames,
+		"Postgres roles the JWKS is mapped to. Neon maps the JWKS to its default roles if not set.")
+	a.Describe(&j.JwtAudience, "Required value of the JWT audience claim.")
+}
+
+type JwksState struct {
+	JwksArgs
+	ID      string   `pulumi:"identifier"`
+	JwksIDs []string `pulumi:"jwks_ids"`
+	Roles   []string `pulumi:"roles"`
+}
+
+func (j *JwksState) Annotate(a infer.Annotator) {
+	a.Describe(&j.ID, "JWKS identifier in the format project_id/jwks_id[,jwks_id].")
+	a.Describe(&j.JwksIDs, "IDs of the JWKS entries, one per branch.")
+	a.Describe(&j.Roles, "Postgres roles the JWKS is mapped to, including the Neon default roles.")
+}
+
+const jwksIDSeparator = ","
+
+// defaultJwksRoleNames the roles Neon maps the JWKS to if the role names are not set.
+var defaultJwksRoleNames = []string{"anonymous", "authenticated", "authenticator"}
+
+func (j Jwks) Create(ctx context.Context, _ string, inputs JwksArgs, preview bool) (
+	id string, output JwksState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		req := sdk.AddProjectJWKSRequest{
+			JwksURL:      inputs.JwksURL,
+			ProviderName: inputs.ProviderName,
+			JwtAudience:  inputs.JwtAudience,
+		}
+		if len(inputs.RoleNames) > 0 {
+			req.RoleNames = &inputs.RoleNames
+		}
+
+		// the Neon API accepts a single branch per JWKS entry
+		branchIDs := []*string{nil}
+		if len(inputs.BranchIDs) > 0 {
+			branchIDs = make([]*string, len(inputs.BranchIDs))
+			for i := range inputs.BranchIDs {
+				branchIDs[i] = &inputs.BranchIDs[i]
+			}
+		}
+
+		output.JwksArgs = inputs
+		for _, branchID := range branchIDs {
+			req.BranchID = branchID
+
+			var resp sdk.JWKSCreationOperation
+			resp, err = c.AddProjectJWKS(inputs.ProjectID, req)
+			if err != nil {
+				break
+			}
+			output.JwksIDs = append(output.JwksIDs, resp.JWKSResponse.Jwks.ID)
+
+			if err = waitForOperations(ctx, c, resp.Operations); err != nil {
+				break
+			}
+		}
+
+		if len(output.JwksIDs) == 0 {
+			return id, output, err
+		}
+
+		id = newCompositeID(inputs.ProjectID, strings.Join(output.JwksIDs, jwksIDSeparator))
+		output.ID = id
+
+		if err != nil {
+			return id, output, infer.ResourceInitFailedError{Reasons: []string{
+				fmt.Sprintf("could not register JWKS for all branches: %v", err),
+			}}
+		}
+
+		output.Roles, err = readJwksRoles(c, inputs.ProjectID, inputs.BranchIDs, inputs.RoleNames)
+		if err != nil {
+			return id, output, infer.ResourceInitFailedError{Reasons: []string{
+				fmt.Sprintf("could not read the roles the JWKS is mapped to: %v", err),
+			}}
+		}
+	}
+
+	return id, output, err
+}
+
+func (j Jwks) Read(ctx context.Context, id string, inputs JwksArgs, state JwksState) (
+	canonicalID string, normalizedInputs JwksArgs, normalizedState JwksState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var ids []string
+		ids, err = parseCompositeID(id, 2)
+		if err != nil {
+			return "", inputs, state, err
+		}
+		projectID, jwksIDs := ids[0], strings.Split(ids[1], jwksIDSeparator)
+
+		var resp sdk.ProjectJWKSResponse
+		resp, err = c.GetProjectJWKS(projectID)
+		switch {
+		case isNotFound(err):
+			// the project was deleted
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		normalizedState = state
+		normalizedState.ID = id
+		normalizedState.ProjectID = projectID
+		normalizedState.JwksIDs = nil
+		normalizedState.BranchIDs = nil
+		for _, v := range resp.Jwks {
+			if slices.Contains(jwksIDs, v.ID) {
+				normalizedState.JwksIDs = append(normalizedState.JwksIDs, v.ID)
+				normalizedState.JwksURL = v.JwksURL
+				normalizedState.ProviderName = v.ProviderName
+				normalizedState.JwtAudience = v.JwtAudience
+				if v.BranchID != nil {
+					normalizedState.BranchIDs = append(normalizedState.BranchIDs, *v.BranchID)
+				}
+			}
+		}
+
+		// the JWKS entries were removed outside of pulumi
+		if len(normalizedState.JwksIDs) == 0 {
+			return "", inputs, state, nil
+		}
+
+		normalizedState.Roles, err = readJwksRoles(c, projectID, normalizedState.BranchIDs, normalizedState.RoleNames)
+		if err != nil {
+			return "", inputs, state, err
+		}
+
+		canonicalID = id
+		normalizedInputs = normalizedState.JwksArgs
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+// readJwksRoles reads the roles the JWKS is mapped to on its branches,
+// the project's default branch is read if the JWKS is accepted on all branches.
+func readJwksRoles(c *sdk.Client, projectID string, branchIDs, roleNames []string) ([]string, error) {
+	if len(roleNames) == 0 {
+		roleNames = defaultJwksRoleNames
+	}
+
+	if len(branchIDs) == 0 {
+		br, err := readDefaultBranch(c, projectID)
+		if err != nil {
+			return nil, err
+		}
+		branchIDs = []string{br.ID}
+	}
+
+	var o []string
+	for _, branchID := range branchIDs {
+		resp, err := c.ListProjectBranchRoles(projectID, branchID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, role := range resp.Roles {
+			if slices.Contains(roleNames, role.Name) && !slices.Contains(o, role.Name) {
+				o = append(o, role.Name)
+			}
+		}
+	}
+
+	slices.Sort(o)
+	return o, nil
+}
+
+func (j Jwks) Delete(ctx context.Context, _ string, props JwksState) error {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		for _, jwksID := range props.JwksIDs {
+			_, err = c.DeleteProjectJWKS(props.ProjectID, jwksID)
+			if isNotFound(err) {
+				err = nil
+			}
+			if err != nil {
+				break
+			}
+		}
+	}
+	return err
+}
+
+func (j Jwks) Diff(_ context.Context, _ string, olds JwksState, news JwksArgs) (p.DiffResponse, error) {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the Neon API does not allow to update JWKS, hence any change leads to replacement
+	for k, changed := range map[string]bool{
+		"project_id":    news.ProjectID != olds.ProjectID,
+		"jwks_url":      news.JwksURL != olds.JwksURL,
+		"provider_name": news.ProviderName != olds.ProviderName,
+		"branch_ids":    !equalUnordered(olds.BranchIDs, news.BranchIDs),
+		"role_names":    !equalUnordered(olds.RoleNames, news.RoleNames),
+		"jwt_audience":  !reflect.DeepEqual(olds.JwtAudience, news.JwtAudience),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readJwksRoles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/foo/branches":
+			_, _ = w.Write([]byte(`{"branches":[{"id":"br-dev","default":false},{"id":"br-main","default":true}]}`))
+		case "/projects/foo/branches/br-main/roles":
+			_, _ = w.Write([]byte(`{"roles":[{"name":"neondb_owner"},{"name":"authenticated"},{"name":"anonymous"}]}`))
+		case "/projects/foo/branches/br-dev/roles":
+			_, _ = w.Write([]byte(`{"roles":[{"name":"neondb_owner"},{"name":"app"},{"name":"authenticated"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"","message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := sdk.NewClient(sdk.Config{
+		Key:        "key",
+		HTTPClient: endpointHTTPClient{endpoint: srv.URL, c: srv.Client()},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		branchIDs []string
+		roleNames []string
+		want      []string
+	}{
+		{
+			name: "default roles on the default branch",
+			want: []string{"anonymous", "authenticated"},
+		},
+		{
+			name:      "requested roles on several branches",
+			branchIDs: []string{"br-main", "br-dev"},
+			roleNames: []string{"app", "authenticated"},
+			want:      []string{"app", "authenticated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readJwksRoles(c, "foo", tt.branchIDs, tt.roleNames)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+// operationPollInterval the interval to check the status of the running operation.
+var operationPollInterval = time.Second
+
+// operationError the error of the Neon operation which did not finish successfully.
+type operationError struct {
+	ID      string
+	Action  string
+	Status  string
+	Message string
+}
+
+func (e operationError) Error() string {
+	msg := fmt.Sprintf("operation %s (%s) %s", e.ID, e.Action, e.Status)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// waitForOperations waits for the operations started by the mutating Neon API call to finish.
+func waitForOperations(ctx context.Context, c *sdk.Client, ops []sdk.Operation) error {
+	for _, op := range ops {
+		if err := waitForOperation(ctx, c, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func waitForOperation(ctx context.Context, c *sdk.Client, op sdk.Operation) error {
+	for !isOperationCompleted(op.Status) {
+		timer := time.NewTimer(operationPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out waiting for the operation %s (%s) to finish, "+
+					"consider increasing the resource's customTimeouts: %w", op.ID, op.Action, ctx.Err())
+			}
+			return fmt.Errorf("stopped waiting for the operation %s (%s) to finish: %w", op.ID, op.Action, ctx.Err())
+		case <-timer.C:
+		}
+
+		resp, err := c.GetProjectOperation(op.ProjectID, op.ID)
+		if err != nil {
+			return fmt.Errorf("could not read the status of the operation %s (%s): %w", op.ID, op.Action, err)
+		}
+		op = resp.Operation
+	}
+
+	switch op.Status {
+	case sdk.OperationStatusFinished, sdk.OperationStatusSkipped:
+		return nil
+	default:
+		e := operationError{ID: op.ID, Action: string(op.Action), Status: string(op.Status)}
+		if op.Error != nil {
+			e.Message = *op.Error
+		}
+		return e
+	}
+}
+
+func isOperationCompleted(status sdk.OperationStatus) bool {
+	switch status {
+	case sdk.OperationStatusFinished, sdk.OperationStatusSkipped, sdk.OperationStatusFailed,
+		sdk.OperationStatusError, sdk.OperationStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// newResourceInitFailedError marks the created resource as partially initialized, so it is kept in the state.
+func newResourceInitFailedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+}
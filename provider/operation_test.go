@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// operationsHTTPClientMock responds with the operation's statuses one by one.
+type operationsHTTPClientMock struct {
+	statuses []string
+	calls    int
+}
+
+func (m *operationsHTTPClientMock) Do(r *http.Request) (*http.Response, error) {
+	status := m.statuses[min(m.calls, len(m.statuses)-1)]
+	m.calls++
+
+	body := `{"operation":{"id":"op-foo","project_id":"prj-foo","action":"start_compute","status":"` + status + `"`
+	if status == string(sdk.OperationStatusFailed) {
+		body += `,"error":"compute failed to start"`
+	}
+	body += "}}"
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func Test_waitForOperations(t *testing.T) {
+	interval := operationPollInterval
+	operationPollInterval = time.Millisecond
+	t.Cleanup(func() { operationPollInterval = interval })
+
+	running := sdk.Operation{
+		ID: "op-foo", ProjectID: "prj-foo", Action: "start_compute", Status: sdk.OperationStatusRunning,
+	}
+
+	tests := []struct {
+		name      string
+		ops       []sdk.Operation
+		statuses  []string
+		wantCalls int
+		wantErr   string
+	}{
+		{
+			name:      "no operations",
+			ops:       nil,
+			wantCalls: 0,
+		},
+		{
+			name: "operation finished already",
+			ops: []sdk.Operation{
+				{ID: "op-bar", Action: "create_branch", Status: sdk.OperationStatusFinished},
+			},
+			wantCalls: 0,
+		},
+		{
+			name:      "operation finished after polling",
+			ops:       []sdk.Operation{running},
+			statuses:  []string{"scheduling", "running", "finished"},
+			wantCalls: 3,
+		},
+		{
+			name:      "operation failed",
+			ops:       []sdk.Operation{running},
+			statuses:  []string{"running", "failed"},
+			wantCalls: 2,
+			wantErr:   "operation op-foo (start_compute) failed: compute failed to start",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &operationsHTTPClientMock{statuses: tt.statuses}
+			c, err := sdk.NewClient(sdk.Config{Key: "foo", HTTPClient: m})
+			assert.NoError(t, err)
+
+			err = waitForOperations(context.Background(), c, tt.ops)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, m.calls)
+		})
+	}
+
+	t.Run("context cancelled", func(t *testing.T) {
+		m := &operationsHTTPClientMock{statuses: []string{"running"}}
+		c, err := sdk.NewClient(sdk.Config{Key: "foo", HTTPClient: m})
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = waitForOperations(ctx, c, []sdk.Operation{running})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		m := &operationsHTTPClientMock{statuses: []string{"running"}}
+		c, err := sdk.NewClient(sdk.Config{Key: "foo", HTTPClient: m})
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+		defer cancel()
+
+		err = waitForOperations(ctx, c, []sdk.Operation{running})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "consider increasing the resource's customTimeouts")
+	})
+}
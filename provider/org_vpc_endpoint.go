@@ -0,0 +1,206 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type OrgVpcEndpoint struct{}
+
+type OrgVpcEndpointArgs struct {
+	OrgID         string `pulumi:"org_id"`
+	RegionID      string `pulumi:"region_id"`
+	VpcEndpointID string `pulumi:"vpc_endpoint_id"`
+	Label         string `pulumi:"label"`
+}
+
+func (v *OrgVpcEndpointArgs) Annotate(a infer.Annotator) {
+	a.Describe(&v.OrgID, "Neon Org ID.")
+	a.Describe(&v.RegionID, "Neon region ID, e.g. aws-us-east-1.")
+	a.Describe(&v.VpcEndpointID, "VPC endpoint ID, e.g. vpce-1234567890abcdef0.")
+	a.Describe(&v.Label, "Descriptive label of the VPC endpoint.")
+}
+
+type OrgVpcEndpointState struct {
+	OrgVpcEndpointArgs
+	ID    string `pulumi:"identifier"`
+	State string `pulumi:"state"`
+}
+
+func (v *OrgVpcEndpointState) Annotate(a infer.Annotator) {
+	a.Describe(&v.ID, "VPC endpoint identifier in the format org_id/region_id/vpc_endpoint_id.")
+	a.Describe(&v.State, "Acceptance state of the VPC endpoint.")
+}
+
+// vpcEndpointStateAccepted the state of the VPC endpoint which can be used to connect to Neon.
+const vpcEndpointStateAccepted = "accepted"
+
+// vpcEndpointDetails the VPC endpoint as returned by the Neon API.
+type vpcEndpointDetails struct {
+	VpcEndpointID string `json:"vpc_endpoint_id"`
+	Label         string `json:"label"`
+	State         string `json:"state"`
+}
+
+type vpcEndpointAssignment struct {
+	Label string `json:"label"`
+}
+
+func orgVpcEndpointPath(orgID, regionID, vpcEndpointID string) string {
+	return "/organizations/" + orgID + "/vpc/region/" + regionID + "/vpc_endpoints/" + vpcEndpointID
+}
+
+func (v OrgVpcEndpoint) Create(ctx context.Context, _ string, inputs OrgVpcEndpointArgs, preview bool) (
+	id string, output OrgVpcEndpointState, err error) {
+	c, err := newAPIClient(ctx)
+
+	if !preview && err == nil {
+		output, err = assignOrgVpcEndpoint(c, inputs)
+		id = output.ID
+	}
+
+	return id, output, err
+}
+
+// assignOrgVpcEndpoint registers the VPC endpoint, or updates its label, and checks that the endpoint is accepted.
+func assignOrgVpcEndpoint(c *apiClient, inputs OrgVpcEndpointArgs) (OrgVpcEndpointState, error) {
+	path := orgVpcEndpointPath(inputs.OrgID, inputs.RegionID, inputs.VpcEndpointID)
+	if err := c.do(http.MethodPost, path, vpcEndpointAssignment{Label: inputs.Label}, nil); err != nil {
+		return OrgVpcEndpointState{}, err
+	}
+
+	var resp vpcEndpointDetails
+	if err := c.do(http.MethodGet, path, nil, &resp); err != nil {
+		return OrgVpcEndpointState{}, err
+	}
+
+	output := newOrgVpcEndpointState(inputs, resp)
+	if output.State != vpcEndpointStateAccepted {
+		return output, infer.ResourceInitFailedError{Reasons: []string{
+			fmt.Sprintf("VPC endpoint %s is registered, but not accepted yet, its current state: %s. "+
+				"Please verify the endpoint's configuration in AWS and re-run the update.",
+				inputs.VpcEndpointID, output.State),
+		}}
+	}
+
+	return output, nil
+}
+
+func newOrgVpcEndpointState(inputs OrgVpcEndpointArgs, v vpcEndpointDetails) OrgVpcEndpointState {
+	o := OrgVpcEndpointState{
+		OrgVpcEndpointArgs: inputs,
+		ID:                 newCompositeID(inputs.OrgID, inputs.RegionID, v.VpcEndpointID),
+		State:              v.State,
+	}
+	o.VpcEndpointID = v.VpcEndpointID
+	o.Label = v.Label
+	return o
+}
+
+func (v OrgVpcEndpoint) Update(ctx context.Context, _ string, olds OrgVpcEndpointState, news OrgVpcEndpointArgs,
+	preview bool) (output OrgVpcEndpointState, err error) {
+	c, err := newAPIClient(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output = olds
+	output.OrgVpcEndpointArgs = news
+	if !preview {
+		output, err = assignOrgVpcEndpoint(c, news)
+	}
+
+	return output, err
+}
+
+func (v OrgVpcEndpoint) Read(ctx context.Context, id string, inputs OrgVpcEndpointArgs, state OrgVpcEndpointState) (
+	canonicalID string, normalizedInputs OrgVpcEndpointArgs, normalizedState OrgVpcEndpointState, err error) {
+	c, err := newAPIClient(ctx)
+	if err == nil {
+		var ids []string
+		ids, err = parseCompositeID(id, 3)
+		if err != nil {
+			return "", inputs, state, err
+		}
+
+		var resp vpcEndpointDetails
+		err = c.do(http.MethodGet, orgVpcEndpointPath(ids[0], ids[1], ids[2]), nil, &resp)
+		switch {
+		case isNotFound(err):
+			// the VPC endpoint was removed outside of pulumi
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		normalizedState = newOrgVpcEndpointState(OrgVpcEndpointArgs{OrgID: ids[0], RegionID: ids[1]}, resp)
+		normalizedInputs = normalizedState.OrgVpcEndpointArgs
+		canonicalID = normalizedState.ID
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (v OrgVpcEndpoint) Delete(ctx context.Context, _ string, props OrgVpcEndpointState) error {
+	c, err := newAPIClient(ctx)
+	if err == nil {
+		err = c.do(http.MethodDelete, orgVpcEndpointPath(props.OrgID, props.RegionID, props.VpcEndpointID), nil, nil)
+		if isNotFound(err) {
+			err = nil
+		}
+	}
+	return err
+}
+
+func (v OrgVpcEndpoint) Diff(_ context.Context, _ string, olds OrgVpcEndpointState, news OrgVpcEndpointArgs) (
+	p.DiffResponse, error) {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	for k, changed := range map[string]bool{
+		"org_id":          news.OrgID != olds.OrgID,
+		"region_id":       news.RegionID != olds.RegionID,
+		"vpc_endpoint_id": news.VpcEndpointID != olds.VpcEndpointID,
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the label is updated in place
+	if news.Label != olds.Label {
+		o.HasChanges = true
+		o.DetailedDiff["label"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgVpcEndpoint_Diff(t *testing.T) {
+	olds := OrgVpcEndpointState{
+		OrgVpcEndpointArgs: OrgVpcEndpointArgs{
+			OrgID:         "org-foo",
+			RegionID:      "aws-us-east-1",
+			VpcEndpointID: "vpce-foo",
+			Label:         "foo",
+		},
+	}
+
+	tests := []struct {
+		name string
+		news OrgVpcEndpointArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			news: olds.OrgVpcEndpointArgs,
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "label changed",
+			news: OrgVpcEndpointArgs{
+				OrgID: "org-foo", RegionID: "aws-us-east-1", VpcEndpointID: "vpce-foo", Label: "bar",
+			},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"label": {Kind: p.Update, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "org changed",
+			news: OrgVpcEndpointArgs{
+				OrgID: "org-bar", RegionID: "aws-us-east-1", VpcEndpointID: "vpce-foo", Label: "foo",
+			},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"org_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "region changed",
+			news: OrgVpcEndpointArgs{
+				OrgID: "org-foo", RegionID: "aws-eu-central-1", VpcEndpointID: "vpce-foo", Label: "foo",
+			},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"region_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "endpoint and label changed",
+			news: OrgVpcEndpointArgs{
+				OrgID: "org-foo", RegionID: "aws-us-east-1", VpcEndpointID: "vpce-bar", Label: "bar",
+			},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"vpc_endpoint_id": {Kind: p.UpdateReplace, InputDiff: true},
+					"label":           {Kind: p.Update, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OrgVpcEndpoint{}.Diff(context.Background(), "", olds, tt.news)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_assignOrgVpcEndpoint(t *testing.T) {
+	const path = "/organizations/org-foo/vpc/region/aws-us-east-1/vpc_endpoints/"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+path+"{id}", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("GET "+path+"vpce-accepted", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"vpc_endpoint_id":"vpce-accepted","label":"foo","state":"accepted"}`))
+	})
+	mux.HandleFunc("GET "+path+"vpce-pending", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"vpc_endpoint_id":"vpce-pending","label":"foo","state":"pending"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := &apiClient{key: "foo", baseURL: srv.URL, httpClient: srv.Client()}
+
+	t.Run("accepted", func(t *testing.T) {
+		got, err := assignOrgVpcEndpoint(c, OrgVpcEndpointArgs{
+			OrgID: "org-foo", RegionID: "aws-us-east-1", VpcEndpointID: "vpce-accepted", Label: "foo",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "org-foo/aws-us-east-1/vpce-accepted", got.ID)
+		assert.Equal(t, vpcEndpointStateAccepted, got.State)
+	})
+
+	t.Run("not accepted yet", func(t *testing.T) {
+		got, err := assignOrgVpcEndpoint(c, OrgVpcEndpointArgs{
+			OrgID: "org-foo", RegionID: "aws-us-east-1", VpcEndpointID: "vpce-pending", Label: "foo",
+		})
+		var wantErr infer.ResourceInitFailedError
+		assert.ErrorAs(t, err, &wantErr)
+		// the state is returned to be saved, the resource is registered nonetheless
+		assert.Equal(t, "org-foo/aws-us-east-1/vpce-pending", got.ID)
+		assert.Equal(t, "pending", got.State)
+	})
+}
+
+func TestOrgVpcEndpoint_Read(t *testing.T) {
+	const path = "/organizations/org-foo/vpc/region/aws-us-east-1/vpc_endpoints/"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+path+"vpce-foo", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"vpc_endpoint_id":"vpce-foo","label":"bar","state":"accepted"}`))
+	})
+	mux.HandleFunc("GET "+path+"vpce-bar", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"","message":"not found"}`))
+	})
+	s := newTestServer(t, mux)
+
+	tests := []struct {
+		name      string
+		id        string
+		wantID    string
+		wantLabel string
+	}{
+		{
+			name:      "registered",
+			id:        "org-foo/aws-us-east-1/vpce-foo",
+			wantID:    "org-foo/aws-us-east-1/vpce-foo",
+			wantLabel: "bar",
+		},
+		{
+			name:   "removed",
+			id:     "org-foo/aws-us-east-1/vpce-bar",
+			wantID: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Read(p.ReadRequest{
+				ID:  tt.id,
+				Urn: newTestURN("OrgVpcEndpoint"),
+				Properties: resource.PropertyMap{
+					"org_id":          resource.NewStringProperty("org-foo"),
+					"region_id":       resource.NewStringProperty("aws-us-east-1"),
+					"vpc_endpoint_id": resource.NewStringProperty("vpce-foo"),
+					"label":           resource.NewStringProperty("foo"),
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.ID)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantLabel, got.Properties["label"].StringValue())
+			}
+		})
+	}
+}
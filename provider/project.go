@@ -17,56 +17,626 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
+	"net/http"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	sdk "github.com/kislerdm/neon-sdk-go"
 	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 )
 
 type Project struct{}
 
 type ProjectArgs struct {
-	Name  *string `pulumi:"name,optional"`
-	OrgID *string `pulumi:"org_id,optional"`
+	Name                   *string `pulumi:"name,optional"`
+	OrgID                  *string `pulumi:"org_id,optional"`
+	RegionID               *string `pulumi:"region_id,optional"`
+	PgVersion              *int    `pulumi:"pg_version,optional"`
+	BlockPublicConnections *bool   `pulumi:"block_public_connections,optional"`
+
+	DefaultEndpointSettings *DefaultEndpointSettings `pulumi:"default_endpoint_settings,optional"`
+	PgSettings              map[string]string        `pulumi:"pg_settings,optional"`
+	PgbouncerSettings       map[string]string        `pulumi:"pgbouncer_settings,optional"`
+
+	AllowedIps            []string `pulumi:"allowed_ips,optional"`
+	ProtectedBranchesOnly *bool    `pulumi:"protected_branches_only,optional"`
+
+	HistoryRetentionSeconds  *int  `pulumi:"history_retention_seconds,optional"`
+	EnableLogicalReplication *bool `pulumi:"enable_logical_replication,optional"`
+
+	Quota *ProjectQuota `pulumi:"quota,optional"`
+
+	StorePasswords      *bool `pulumi:"store_passwords,optional"`
+	BlockVpcConnections *bool `pulumi:"block_vpc_connections,optional"`
+	Hipaa               *bool `pulumi:"hipaa,optional"`
+
+	MaintenanceWindow *MaintenanceWindow `pulumi:"maintenance_window,optional"`
+
+	DefaultBranchName   *string `pulumi:"default_branch_name,optional"`
+	DefaultDatabaseName *string `pulumi:"default_database_name,optional"`
+	DefaultRoleName     *string `pulumi:"default_role_name,optional"`
+
+	Provisioner         *Provisioner  `pulumi:"provisioner,optional"`
+	DefaultEndpointType *EndpointType `pulumi:"default_endpoint_type,optional"`
 }
 
 func (pr *ProjectArgs) Annotate(a infer.Annotator) {
 	a.Describe(&pr.Name, "Neon project name.")
-	a.Describe(&pr.OrgID, "Neon Org ID.")
+	a.Describe(&pr.OrgID,
+		"Neon Org ID. The project is transferred to the org if the ID changes. "+
+			"Note that the project cannot be transferred from the org to the personal account, "+
+			"hence it is replaced if the ID is removed.")
+	a.Describe(&pr.RegionID, "Neon region ID, e.g. aws-us-east-1. The account's default region is used if not set.")
+	a.Describe(&pr.PgVersion, "Postgres major version. The Neon default version is used if not set.")
+	a.Describe(&pr.BlockPublicConnections,
+		"Whether to block connections from the public internet, so only the connections from the VPC endpoints "+
+			"the project is restricted to are accepted.")
+	a.Describe(&pr.DefaultEndpointSettings, "Settings of the endpoints created in the project by default.")
+	a.Describe(&pr.PgSettings, "Postgres settings of the endpoints created in the project by default.")
+	a.Describe(&pr.PgbouncerSettings, "PgBouncer settings of the endpoints created in the project by default.")
+	a.Describe(&pr.AllowedIps,
+		"IP addresses, CIDRs, or IP ranges, e.g. 192.168.1.1-192.168.1.15, allowed to connect to the endpoints. "+
+			"All IP addresses are allowed if the list is empty.")
+	a.Describe(&pr.ProtectedBranchesOnly, "Whether to apply the IP allow-list to the protected branches only.")
+	a.Describe(&pr.HistoryRetentionSeconds,
+		"Number of seconds to retain the history for point-in-time recovery. The Neon default is used if not set.")
+	a.Describe(&pr.EnableLogicalReplication,
+		"Whether to enable logical replication. Note that logical replication cannot be disabled once enabled.")
+	a.Describe(&pr.Quota, "Consumption quota of the project.")
+	a.Describe(&pr.StorePasswords,
+		"Whether to store the roles' passwords in Neon. The project is re-created if the setting changes.")
+	a.Describe(&pr.BlockVpcConnections,
+		"Whether to block connections from the VPC endpoints, so only the connections from the public internet "+
+			"are accepted.")
+	a.Describe(&pr.Hipaa,
+		"Whether to enable HIPAA compliance features. Note that HIPAA compliance cannot be disabled once enabled.")
+	a.Describe(&pr.MaintenanceWindow, "Time window when Neon performs the maintenance of the project's endpoints.")
+	a.Describe(&pr.DefaultBranchName,
+		"Neon default branch's name. The Neon default name is used if not set. "+
+			"The project is re-created if the name changes.")
+	a.Describe(&pr.DefaultDatabaseName,
+		"Neon default database's name. The Neon default name is used if not set. "+
+			"The project is re-created if the name changes.")
+	a.Describe(&pr.DefaultRoleName,
+		"Neon default role's name. The Neon default name is used if not set. "+
+			"The project is re-created if the name changes.")
+	a.Describe(&pr.Provisioner,
+		"Compute provisioner of the project's endpoints. Only the default endpoint is changed in place "+
+			"if the provisioner changes.")
+	a.Describe(&pr.DefaultEndpointType,
+		"Type of the endpoint created on the default branch. The default endpoint is re-created "+
+			"if the type changes.")
+}
+
+type MaintenanceWindow struct {
+	Weekdays  []int  `pulumi:"weekdays"`
+	StartTime string `pulumi:"start_time"`
+	EndTime   string `pulumi:"end_time"`
+}
+
+func (w *MaintenanceWindow) Annotate(a infer.Annotator) {
+	a.Describe(&w.Weekdays, "Weekdays when the maintenance window is active, where 1 is Monday, and 7 is Sunday.")
+	a.Describe(&w.StartTime, "Start time of the maintenance window in UTC, in the format HH:MM.")
+	a.Describe(&w.EndTime, "End time of the maintenance window in UTC, in the format HH:MM.")
+}
+
+// changed checks if the new maintenance window differs from the old window.
+func (w *MaintenanceWindow) changed(news *MaintenanceWindow) bool {
+	if news == nil {
+		return false
+	}
+
+	if w == nil {
+		return true
+	}
+
+	return w.StartTime != news.StartTime || w.EndTime != news.EndTime || !equalUnordered(w.Weekdays, news.Weekdays)
+}
+
+// validate checks the format of the maintenance window.
+func (w *MaintenanceWindow) validate() []p.CheckFailure {
+	if w == nil {
+		return nil
+	}
+
+	var o []p.CheckFailure
+	if len(w.Weekdays) == 0 {
+		o = append(o, p.CheckFailure{
+			Property: "maintenance_window.weekdays",
+			Reason:   "at least one weekday must be set",
+		})
+	}
+
+	for i, weekday := range w.Weekdays {
+		if weekday < 1 || weekday > 7 {
+			o = append(o, p.CheckFailure{
+				Property: fmt.Sprintf("maintenance_window.weekdays[%d]", i),
+				Reason:   fmt.Sprintf("%d is not a valid weekday, expected a number from 1 (Monday) to 7 (Sunday)", weekday),
+			})
+		}
+	}
+
+	start, errStart := time.Parse(maintenanceWindowTimeFormat, w.StartTime)
+	if errStart != nil {
+		o = append(o, p.CheckFailure{
+			Property: "maintenance_window.start_time",
+			Reason:   fmt.Sprintf("%q is not a valid time, expected the format HH:MM", w.StartTime),
+		})
+	}
+
+	end, errEnd := time.Parse(maintenanceWindowTimeFormat, w.EndTime)
+	if errEnd != nil {
+		o = append(o, p.CheckFailure{
+			Property: "maintenance_window.end_time",
+			Reason:   fmt.Sprintf("%q is not a valid time, expected the format HH:MM", w.EndTime),
+		})
+	}
+
+	if errStart != nil || errEnd != nil {
+		return o
+	}
+
+	// the window crosses midnight and ends on the next day if the end time is before the start time
+	duration := end.Sub(start)
+	if duration < 0 {
+		duration += day
+	}
+
+	if duration == 0 {
+		return append(o, p.CheckFailure{
+			Property: "maintenance_window.end_time",
+			Reason:   "the end time must differ from the start time",
+		})
+	}
+
+	return append(o, w.validateOverlap(duration)...)
+}
+
+// validateOverlap checks that the windows of the given duration, which start at the same time on every weekday,
+// do not overlap on the weekly timeline, including the window which crosses midnight from Sunday to Monday.
+func (w *MaintenanceWindow) validateOverlap(duration time.Duration) []p.CheckFailure {
+	const week = 7 * day
+
+	var o []p.CheckFailure
+	for j, weekdayNext := range w.Weekdays {
+		for _, weekday := range w.Weekdays[:j] {
+			if weekday < 1 || weekday > 7 || weekdayNext < 1 || weekdayNext > 7 {
+				continue
+			}
+
+			// distance between the starts of both windows on the weekly timeline
+			distance := time.Duration(weekdayNext-weekday) * day
+			distance = (distance%week + week) % week
+			if distance < duration || week-distance < duration {
+				o = append(o, p.CheckFailure{
+					Property: fmt.Sprintf("maintenance_window.weekdays[%d]", j),
+					Reason: fmt.Sprintf("the window on weekday %d overlaps with the window on weekday %d",
+						weekdayNext, weekday),
+				})
+				break
+			}
+		}
+	}
+
+	return o
+}
+
+const day = 24 * time.Hour
+
+const maintenanceWindowTimeFormat = "15:04"
+
+func newMaintenanceWindow(v *sdk.MaintenanceWindow) *MaintenanceWindow {
+	if v == nil {
+		return nil
+	}
+
+	return &MaintenanceWindow{
+		Weekdays:  v.Weekdays,
+		StartTime: v.StartTime,
+		EndTime:   v.EndTime,
+	}
+}
+
+func newSDKMaintenanceWindow(v *MaintenanceWindow) *sdk.MaintenanceWindow {
+	if v == nil {
+		return nil
+	}
+
+	return &sdk.MaintenanceWindow{
+		Weekdays:  slices.Clone(v.Weekdays),
+		StartTime: v.StartTime,
+		EndTime:   v.EndTime,
+	}
+}
+
+type ProjectQuota struct {
+	ActiveTimeSeconds  *int `pulumi:"active_time_seconds,optional"`
+	ComputeTimeSeconds *int `pulumi:"compute_time_seconds,optional"`
+	WrittenDataBytes   *int `pulumi:"written_data_bytes,optional"`
+	DataTransferBytes  *int `pulumi:"data_transfer_bytes,optional"`
+	LogicalSizeBytes   *int `pulumi:"logical_size_bytes,optional"`
+}
+
+func (q *ProjectQuota) Annotate(a infer.Annotator) {
+	a.Describe(&q.ActiveTimeSeconds, "Total wall-clock time in seconds the project's endpoints can be active.")
+	a.Describe(&q.ComputeTimeSeconds, "Total CPU time in seconds the project's endpoints can spend.")
+	a.Describe(&q.WrittenDataBytes, "Total amount of data in bytes written to all the project's branches.")
+	a.Describe(&q.DataTransferBytes,
+		"Total amount of data in bytes transferred from all the project's branches using the proxy.")
+	a.Describe(&q.LogicalSizeBytes, "Limit of the logical size in bytes of every project's branch.")
+}
+
+// changed checks if any of the quotas set in the new inputs differ from the old quotas.
+func (q *ProjectQuota) changed(news *ProjectQuota) bool {
+	if news == nil {
+		return false
+	}
+
+	if q == nil {
+		q = &ProjectQuota{}
+	}
+
+	return optionalChanged(q.ActiveTimeSeconds, news.ActiveTimeSeconds) ||
+		optionalChanged(q.ComputeTimeSeconds, news.ComputeTimeSeconds) ||
+		optionalChanged(q.WrittenDataBytes, news.WrittenDataBytes) ||
+		optionalChanged(q.DataTransferBytes, news.DataTransferBytes) ||
+		optionalChanged(q.LogicalSizeBytes, news.LogicalSizeBytes)
+}
+
+func newProjectQuota(v *sdk.ProjectQuota) *ProjectQuota {
+	if v == nil {
+		return nil
+	}
+
+	return &ProjectQuota{
+		ActiveTimeSeconds:  int64ToIntPtr(v.ActiveTimeSeconds),
+		ComputeTimeSeconds: int64ToIntPtr(v.ComputeTimeSeconds),
+		WrittenDataBytes:   int64ToIntPtr(v.WrittenDataBytes),
+		DataTransferBytes:  int64ToIntPtr(v.DataTransferBytes),
+		LogicalSizeBytes:   int64ToIntPtr(v.LogicalSizeBytes),
+	}
+}
+
+func newSDKProjectQuota(v *ProjectQuota) *sdk.ProjectQuota {
+	if v == nil {
+		return nil
+	}
+
+	return &sdk.ProjectQuota{
+		ActiveTimeSeconds:  intToInt64Ptr(v.ActiveTimeSeconds),
+		ComputeTimeSeconds: intToInt64Ptr(v.ComputeTimeSeconds),
+		WrittenDataBytes:   intToInt64Ptr(v.WrittenDataBytes),
+		DataTransferBytes:  intToInt64Ptr(v.DataTransferBytes),
+		LogicalSizeBytes:   intToInt64Ptr(v.LogicalSizeBytes),
+	}
+}
+
+func int64ToIntPtr(v *int64) *int {
+	if v == nil {
+		return nil
+	}
+	o := int(*v)
+	return &o
+}
+
+func intToInt64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	o := int64(*v)
+	return &o
+}
+
+type DefaultEndpointSettings struct {
+	AutoscalingLimitMinCu *float64 `pulumi:"autoscaling_limit_min_cu,optional"`
+	AutoscalingLimitMaxCu *float64 `pulumi:"autoscaling_limit_max_cu,optional"`
+	SuspendTimeoutSeconds *int     `pulumi:"suspend_timeout_seconds,optional"`
+}
+
+func (s *DefaultEndpointSettings) Annotate(a infer.Annotator) {
+	a.Describe(&s.AutoscalingLimitMinCu, "Minimum number of Compute Units.")
+	a.Describe(&s.AutoscalingLimitMaxCu, "Maximum number of Compute Units.")
+	a.Describe(&s.SuspendTimeoutSeconds,
+		"Duration of inactivity in seconds after which the endpoint is suspended. "+
+			"0 sets the Neon default, -1 disables the suspension.")
+}
+
+// changed checks if any of the settings set in the new inputs differ from the old settings.
+func (s *DefaultEndpointSettings) changed(news *DefaultEndpointSettings) bool {
+	if news == nil {
+		return false
+	}
+
+	if s == nil {
+		s = &DefaultEndpointSettings{}
+	}
+
+	return optionalChanged(s.AutoscalingLimitMinCu, news.AutoscalingLimitMinCu) ||
+		optionalChanged(s.AutoscalingLimitMaxCu, news.AutoscalingLimitMaxCu) ||
+		optionalChanged(s.SuspendTimeoutSeconds, news.SuspendTimeoutSeconds)
+}
+
+func newDefaultEndpointSettings(v *sdk.DefaultEndpointSettings) *DefaultEndpointSettings {
+	if v == nil {
+		return nil
+	}
+
+	o := &DefaultEndpointSettings{}
+	if v.AutoscalingLimitMinCu != nil {
+		minCu := float64(*v.AutoscalingLimitMinCu)
+		o.AutoscalingLimitMinCu = &minCu
+	}
+
+	if v.AutoscalingLimitMaxCu != nil {
+		maxCu := float64(*v.AutoscalingLimitMaxCu)
+		o.AutoscalingLimitMaxCu = &maxCu
+	}
+
+	if v.SuspendTimeoutSeconds != nil {
+		suspendTimeout := int(*v.SuspendTimeoutSeconds)
+		o.SuspendTimeoutSeconds = &suspendTimeout
+	}
+
+	return o
+}
+
+// setDefaultEndpointSettings sets the project's default endpoint settings returned by the Neon API.
+func (pr *ProjectArgs) setDefaultEndpointSettings(v *sdk.DefaultEndpointSettings) {
+	pr.DefaultEndpointSettings = newDefaultEndpointSettings(v)
+	pr.PgSettings = nil
+	pr.PgbouncerSettings = nil
+	if v != nil {
+		if v.PgSettings != nil {
+			pr.PgSettings = newSettingsMap(*v.PgSettings)
+		}
+		if v.PgbouncerSettings != nil {
+			pr.PgbouncerSettings = newSettingsMap(*v.PgbouncerSettings)
+		}
+	}
+}
+
+// newSDKDefaultEndpointSettings defines the project's default endpoint settings for the Neon API request.
+func newSDKDefaultEndpointSettings(inputs ProjectArgs) *sdk.DefaultEndpointSettings {
+	if inputs.DefaultEndpointSettings == nil && inputs.PgSettings == nil && inputs.PgbouncerSettings == nil {
+		return nil
+	}
+
+	o := &sdk.DefaultEndpointSettings{}
+	if inputs.DefaultEndpointSettings != nil {
+		o.SuspendTimeoutSeconds = newSuspendTimeoutSeconds(inputs.DefaultEndpointSettings.SuspendTimeoutSeconds)
+		o.AutoscalingLimitMinCu, o.AutoscalingLimitMaxCu = newComputeUnits(
+			inputs.DefaultEndpointSettings.AutoscalingLimitMinCu, inputs.DefaultEndpointSettings.AutoscalingLimitMaxCu)
+	}
+
+	if inputs.PgSettings != nil {
+		v := make(sdk.PgSettingsData, len(inputs.PgSettings))
+		for k, setting := range inputs.PgSettings {
+			v[k] = setting
+		}
+		o.PgSettings = &v
+	}
+
+	if inputs.PgbouncerSettings != nil {
+		v := make(sdk.PgbouncerSettingsData, len(inputs.PgbouncerSettings))
+		for k, setting := range inputs.PgbouncerSettings {
+			v[k] = setting
+		}
+		o.PgbouncerSettings = &v
+	}
+
+	return o
+}
+
+// settingsChange defines the change of the settings map key by key.
+// The settings are not changed if the map was removed from the manifest.
+func settingsChange(name string, olds, news map[string]string, inputDiff bool) map[string]p.PropertyDiff {
+	o := make(map[string]p.PropertyDiff)
+	if news == nil {
+		return o
+	}
+
+	for k, v := range news {
+		oldV, ok := olds[k]
+		switch {
+		case !ok:
+			o[settingPath(name, k)] = p.PropertyDiff{Kind: p.Add, InputDiff: inputDiff}
+		case oldV != v:
+			o[settingPath(name, k)] = p.PropertyDiff{Kind: p.Update, InputDiff: inputDiff}
+		}
+	}
+
+	for k := range olds {
+		if _, ok := news[k]; !ok {
+			o[settingPath(name, k)] = p.PropertyDiff{Kind: p.Delete, InputDiff: inputDiff}
+		}
+	}
+
+	return o
+}
+
+// settingPath defines the property path of the map's key.
+func settingPath(name, key string) string {
+	for _, r := range key {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return fmt.Sprintf("%s[%q]", name, key)
+		}
+	}
+	return name + "." + key
 }
 
 type ProjectState struct {
 	ProjectArgs
-	inputState                ProjectArgs
-	ID                        string `pulumi:"identifier"`
-	DefaultBranchName         string `pulumi:"default_branch_name"`
-	DefaultRoleName           string `pulumi:"default_role_name"`
-	DefaultRolePassword       string `pulumi:"default_role_password"`
-	DefaultDatabaseName       string `pulumi:"default_database_name"`
-	ConnectionURI             string `pulumi:"connection_uri"`
-	ConnectionURIPooler       string `pulumi:"connection_uri_pooler"`
-	DefaultEndpointHost       string `pulumi:"default_endpoint_host"`
-	DefaultEndpointHostPooler string `pulumi:"default_endpoint_host_pooler"`
+	inputState ProjectArgs
+	// the default names are always known once the project is created, hence they shadow the optional inputs
+	DefaultBranchName         string   `pulumi:"default_branch_name"`
+	DefaultDatabaseName       string   `pulumi:"default_database_name"`
+	DefaultRoleName           string   `pulumi:"default_role_name"`
+	ID                        string   `pulumi:"identifier"`
+	DefaultRolePassword       string   `pulumi:"default_role_password"`
+	ConnectionURI             string   `pulumi:"connection_uri"`
+	ConnectionURIPooler       string   `pulumi:"connection_uri_pooler"`
+	DefaultEndpointHost       string   `pulumi:"default_endpoint_host"`
+	DefaultEndpointHostPooler string   `pulumi:"default_endpoint_host_pooler"`
+	VpcEndpointRestrictions   []string `pulumi:"vpc_endpoint_restrictions"`
 }
 
 func (pr *ProjectState) Annotate(a infer.Annotator) {
 	a.Describe(&pr.ID, "Project ID.")
 	a.Describe(&pr.Name, "Neon project name.")
 	a.Describe(&pr.OrgID, "Neon Org ID.")
+	a.Describe(&pr.RegionID, "Neon region ID.")
+	a.Describe(&pr.PgVersion, "Postgres major version.")
 	a.Describe(&pr.DefaultBranchName, "Neon default branch's name.")
 	a.Describe(&pr.DefaultDatabaseName, "Neon default database's name.")
 	a.Describe(&pr.DefaultRoleName, "Neon default role's name.")
-	a.Describe(&pr.DefaultRolePassword, "Neon default role's password.")
-	a.Describe(&pr.ConnectionURI, "URI to connect to the default database using the default endpoint.")
+	a.Describe(&pr.DefaultRolePassword,
+		"Neon default role's password. It is empty if the project does not store the passwords.")
+	a.Describe(&pr.ConnectionURI, "URI to connect to the default database using the default endpoint. "+
+		"It does not contain the password if the project does not store the passwords.")
 	a.Describe(&pr.ConnectionURIPooler,
 		"URI to connect to the default database using the default endpoint in the pooler mode.")
 	a.Describe(&pr.DefaultEndpointHost, "The default endpoint's host.")
 	a.Describe(&pr.DefaultEndpointHostPooler, "The default endpoint's host with the pooler mode active.")
+	a.Describe(&pr.BlockPublicConnections,
+		"Whether to block connections from the public internet, so only the connections from the VPC endpoints "+
+			"the project is restricted to are accepted.")
+	a.Describe(&pr.VpcEndpointRestrictions, "IDs of the VPC endpoints the project is restricted to. "+
+		"The restrictions are managed by the ProjectVpcEndpointRestriction resources, "+
+		"which are recreated if removed outside of pulumi.")
+	a.Describe(&pr.DefaultEndpointSettings, "Settings of the endpoints created in the project by default.")
+	a.Describe(&pr.PgSettings, "Postgres settings of the endpoints created in the project by default.")
+	a.Describe(&pr.PgbouncerSettings, "PgBouncer settings of the endpoints created in the project by default.")
+	a.Describe(&pr.AllowedIps, "IP addresses, CIDRs, or IP ranges allowed to connect to the endpoints.")
+	a.Describe(&pr.ProtectedBranchesOnly, "Whether the IP allow-list is applied to the protected branches only.")
+	a.Describe(&pr.HistoryRetentionSeconds, "Number of seconds to retain the history for point-in-time recovery.")
+	a.Describe(&pr.EnableLogicalReplication, "Whether logical replication is enabled.")
+	a.Describe(&pr.Quota, "Consumption quota of the project.")
+	a.Describe(&pr.StorePasswords, "Whether the roles' passwords are stored in Neon.")
+	a.Describe(&pr.BlockVpcConnections, "Whether connections from the VPC endpoints are blocked.")
+	a.Describe(&pr.Hipaa, "Whether HIPAA compliance features are enabled.")
+	a.Describe(&pr.MaintenanceWindow, "Time window when Neon performs the maintenance of the project's endpoints.")
+	a.Describe(&pr.Provisioner, "Compute provisioner of the project's default endpoint.")
+	a.Describe(&pr.DefaultEndpointType, "Type of the default endpoint.")
+}
+
+func (pr Project) Check(ctx context.Context, _ string, _, newInputs resource.PropertyMap) (
+	ProjectArgs, []p.CheckFailure, error) {
+	inputs, failures, err := infer.DefaultCheck[ProjectArgs](ctx, newInputs)
+	if err != nil {
+		return inputs, failures, err
+	}
+
+	failures = append(failures, validateAllowedIps(inputs.AllowedIps)...)
+	failures = append(failures, inputs.MaintenanceWindow.validate()...)
+	failures = append(failures, validateEnum("provisioner", inputs.Provisioner)...)
+	failures = append(failures, validateEnum("default_endpoint_type", inputs.DefaultEndpointType)...)
+	return inputs, failures, nil
+}
+
+// validateAllowedIps checks that every entry of the IP allow-list is an IP address, a CIDR, or an IP range.
+func validateAllowedIps(ips []string) []p.CheckFailure {
+	var o []p.CheckFailure
+	for i, ip := range ips {
+		if err := validateAllowedIP(ip); err != nil {
+			o = append(o, p.CheckFailure{
+				Property: fmt.Sprintf("allowed_ips[%d]", i),
+				Reason:   err.Error(),
+			})
+		}
+	}
+	return o
+}
+
+func validateAllowedIP(ip string) error {
+	if start, end, ok := strings.Cut(ip, "-"); ok {
+		startAddr, errStart := netip.ParseAddr(strings.TrimSpace(start))
+		endAddr, errEnd := netip.ParseAddr(strings.TrimSpace(end))
+		switch {
+		case errStart != nil || errEnd != nil:
+			return fmt.Errorf("%q is not a valid IP range, expected the format: 192.168.1.1-192.168.1.15", ip)
+		case startAddr.Is4() != endAddr.Is4():
+			return fmt.Errorf("%q is not a valid IP range, both addresses must be of the same IP version", ip)
+		case startAddr.Compare(endAddr) > 0:
+			return fmt.Errorf("%q is not a valid IP range, the start address must not exceed the end address", ip)
+		}
+		return nil
+	}
+
+	if strings.Contains(ip, "/") {
+		if _, err := netip.ParsePrefix(ip); err != nil {
+			return fmt.Errorf("%q is not a valid CIDR", ip)
+		}
+		return nil
+	}
+
+	if _, err := netip.ParseAddr(ip); err != nil {
+		return fmt.Errorf("%q is not a valid IP address", ip)
+	}
+	return nil
+}
+
+// setSettings sets the project's settings returned by the Neon API.
+func (pr *ProjectArgs) setSettings(v *sdk.ProjectSettingsData) {
+	pr.AllowedIps = nil
+	pr.ProtectedBranchesOnly = nil
+	pr.EnableLogicalReplication = nil
+	pr.Quota = nil
+	pr.MaintenanceWindow = nil
+	if v == nil {
+		return
+	}
+
+	if v.AllowedIps != nil {
+		if v.AllowedIps.Ips != nil {
+			pr.AllowedIps = *v.AllowedIps.Ips
+		}
+		pr.ProtectedBranchesOnly = v.AllowedIps.ProtectedBranchesOnly
+	}
+	pr.EnableLogicalReplication = v.EnableLogicalReplication
+	pr.Quota = newProjectQuota(v.Quota)
+	pr.MaintenanceWindow = newMaintenanceWindow(v.MaintenanceWindow)
+}
+
+// newSDKProjectSettings defines the project's settings for the Neon API request.
+func newSDKProjectSettings(inputs ProjectArgs) *sdk.ProjectSettingsData {
+	o := &sdk.ProjectSettingsData{
+		EnableLogicalReplication: inputs.EnableLogicalReplication,
+		Quota:                    newSDKProjectQuota(inputs.Quota),
+		MaintenanceWindow:        newSDKMaintenanceWindow(inputs.MaintenanceWindow),
+	}
+
+	if inputs.AllowedIps != nil || inputs.ProtectedBranchesOnly != nil {
+		o.AllowedIps = &sdk.AllowedIps{
+			ProtectedBranchesOnly: inputs.ProtectedBranchesOnly,
+		}
+		if inputs.AllowedIps != nil {
+			ips := slices.Clone(inputs.AllowedIps)
+			o.AllowedIps.Ips = &ips
+		}
+	}
+
+	if reflect.DeepEqual(*o, sdk.ProjectSettingsData{}) {
+		return nil
+	}
+	return o
+}
+
+func newHistoryRetentionSeconds(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	o := int32(*v)
+	return &o
 }
 
 func (pr Project) Create(ctx context.Context, _ string, inputs ProjectArgs, preview bool) (
@@ -74,11 +644,26 @@ func (pr Project) Create(ctx context.Context, _ string, inputs ProjectArgs, prev
 	c, err := NewSDKClient(ctx)
 
 	if !preview && err == nil {
+		var pgVersion *sdk.PgVersion
+		if inputs.PgVersion != nil {
+			v := sdk.PgVersion(*inputs.PgVersion)
+			pgVersion = &v
+		}
+
 		var resp sdk.CreatedProject
 		resp, err = c.CreateProject(sdk.ProjectCreateRequest{
 			Project: sdk.ProjectCreateRequestProject{
-				Name:  inputs.Name,
-				OrgID: inputs.OrgID,
+				Name:      inputs.Name,
+				OrgID:     inputs.OrgID,
+				RegionID:  inputs.RegionID,
+				PgVersion: pgVersion,
+
+				DefaultEndpointSettings: newSDKDefaultEndpointSettings(inputs),
+				Settings:                newSDKProjectSettings(inputs),
+				HistoryRetentionSeconds: newHistoryRetentionSeconds(inputs.HistoryRetentionSeconds),
+				StorePasswords:          inputs.StorePasswords,
+				Branch:                  newSDKProjectBranch(inputs),
+				Provisioner:             newSDKProvisioner(inputs.Provisioner),
 			},
 		})
 
@@ -90,6 +675,12 @@ func (pr Project) Create(ctx context.Context, _ string, inputs ProjectArgs, prev
 		output.ID = resp.ProjectResponse.Project.ID
 		output.OrgID = resp.ProjectResponse.Project.OrgID
 		output.Name = &resp.ProjectResponse.Project.Name
+		output.RegionID = &resp.ProjectResponse.Project.RegionID
+		output.PgVersion = newPgVersion(resp.ProjectResponse.Project.PgVersion)
+		output.setDefaultEndpointSettings(resp.ProjectResponse.Project.DefaultEndpointSettings)
+		output.setSettings(resp.ProjectResponse.Project.Settings)
+		output.HistoryRetentionSeconds = newIntPtr(resp.ProjectResponse.Project.HistoryRetentionSeconds)
+		output.StorePasswords = &resp.ProjectResponse.Project.StorePasswords
 		output.DefaultDatabaseName = resp.DatabasesResponse.Databases[0].Name
 		output.DefaultRoleName = resp.DatabasesResponse.Databases[0].OwnerName
 		output.DefaultBranchName = resp.BranchResponse.Branch.Name
@@ -103,19 +694,183 @@ func (pr Project) Create(ctx context.Context, _ string, inputs ProjectArgs, prev
 			}
 		}
 
-		host := resp.EndpointsResponse.Endpoints[0].Host
-		output.DefaultEndpointHost = host
-		output.DefaultEndpointHostPooler = newHostPooler(host)
+		defaultEndpoint := resp.EndpointsResponse.Endpoints[0]
+		output.DefaultEndpointType = newEndpointType(defaultEndpoint.Type)
+		output.Provisioner = newProvisioner(defaultEndpoint.Provisioner)
+		output.DefaultEndpointHost = defaultEndpoint.Host
+		output.DefaultEndpointHostPooler = newHostPooler(defaultEndpoint.Host)
 		output.ConnectionURI = resp.ConnectionURIs[0].ConnectionURI
 		output.ConnectionURIPooler = newURIPooler(output.ConnectionURI)
+		// the restrictions are assigned by the ProjectVpcEndpointRestriction resources once the project exists
+		output.VpcEndpointRestrictions = []string{}
 
 		// preserve the inputs
 		output.inputState = inputs
+
+		// the project is created, but its default branch and endpoint may not be ready yet
+		if err = waitForOperations(ctx, c, resp.Operations); err != nil {
+			return id, output, newResourceInitFailedError(err)
+		}
+
+		// Neon creates the read-write endpoint by default, hence it is replaced if another type is requested
+		if optionalChanged(output.DefaultEndpointType, inputs.DefaultEndpointType) {
+			defaultEndpoint, err = replaceDefaultEndpoint(ctx, c, id, resp.BranchResponse.Branch.ID,
+				defaultEndpoint.ID, inputs)
+			if err == nil {
+				output.DefaultEndpointType = newEndpointType(defaultEndpoint.Type)
+				output.Provisioner = newProvisioner(defaultEndpoint.Provisioner)
+				output.DefaultEndpointHost = defaultEndpoint.Host
+				output.DefaultEndpointHostPooler = newHostPooler(defaultEndpoint.Host)
+
+				var respURI sdk.ConnectionURIResponse
+				pooled := false
+				respURI, err = c.GetConnectionURI(id, &resp.BranchResponse.Branch.ID, &defaultEndpoint.ID,
+					output.DefaultDatabaseName, output.DefaultRoleName, &pooled)
+				if err == nil {
+					output.ConnectionURI = respURI.URI
+					output.ConnectionURIPooler = newURIPooler(respURI.URI)
+				}
+			}
+			if err != nil {
+				return id, output, infer.ResourceInitFailedError{Reasons: []string{
+					fmt.Sprintf("could not set the default endpoint type: %v", err),
+				}}
+			}
+		}
+
+		if inputs.hasSettingsExt() {
+			if err = applyProjectSettingsExt(ctx, c, id, inputs); err != nil {
+				return id, output, infer.ResourceInitFailedError{Reasons: []string{
+					fmt.Sprintf("could not apply the project settings: %v", err),
+				}}
+			}
+			output.setSettingsExt(inputs)
+		}
 	}
 
 	return id, output, err
 }
 
+// replaceDefaultEndpoint creates the endpoint of the requested type on the default branch,
+// and deletes the endpoint created by Neon.
+func replaceDefaultEndpoint(ctx context.Context, c *sdk.Client, projectID, branchID, endpointID string,
+	inputs ProjectArgs) (sdk.Endpoint, error) {
+	resp, err := c.CreateProjectEndpoint(projectID, sdk.EndpointCreateRequest{
+		Endpoint: sdk.EndpointCreateRequestEndpoint{
+			BranchID:    branchID,
+			Type:        sdk.EndpointType(*inputs.DefaultEndpointType),
+			Provisioner: newSDKProvisioner(inputs.Provisioner),
+		},
+	})
+	if err == nil {
+		err = waitForOperations(ctx, c, resp.Operations)
+	}
+	if err != nil {
+		return sdk.Endpoint{}, err
+	}
+
+	respDelete, err := c.DeleteProjectEndpoint(projectID, endpointID)
+	if err == nil {
+		err = waitForOperations(ctx, c, respDelete.Operations)
+	}
+	if err != nil {
+		return sdk.Endpoint{}, err
+	}
+
+	return resp.EndpointResponse.Endpoint, nil
+}
+
+// projectSettingsExt the project settings which are not covered by the SDK yet.
+type projectSettingsExt struct {
+	BlockPublicConnections *bool `json:"block_public_connections,omitempty"`
+	BlockVpcConnections    *bool `json:"block_vpc_connections,omitempty"`
+	Hipaa                  *bool `json:"hipaa,omitempty"`
+}
+
+type projectExt struct {
+	Project struct {
+		Settings projectSettingsExt `json:"settings"`
+	} `json:"project"`
+}
+
+func applyProjectSettingsExt(ctx context.Context, c *sdk.Client, id string, inputs ProjectArgs) error {
+	cExt, err := newAPIClient(ctx)
+	if err == nil {
+		var req projectExt
+		req.Project.Settings = projectSettingsExt{
+			BlockPublicConnections: inputs.BlockPublicConnections,
+			BlockVpcConnections:    inputs.BlockVpcConnections,
+			Hipaa:                  inputs.Hipaa,
+		}
+
+		var resp sdk.OperationsResponse
+		err = cExt.do(http.MethodPatch, "/projects/"+id, req, &resp)
+		if err == nil {
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+	return err
+}
+
+// hasSettingsExt checks if any of the settings not covered by the SDK is set.
+func (pr *ProjectArgs) hasSettingsExt() bool {
+	return pr.BlockPublicConnections != nil || pr.BlockVpcConnections != nil || pr.Hipaa != nil
+}
+
+// setSettingsExt sets the settings not covered by the SDK which were applied.
+func (pr *ProjectArgs) setSettingsExt(inputs ProjectArgs) {
+	if inputs.BlockPublicConnections != nil {
+		pr.BlockPublicConnections = inputs.BlockPublicConnections
+	}
+	if inputs.BlockVpcConnections != nil {
+		pr.BlockVpcConnections = inputs.BlockVpcConnections
+	}
+	if inputs.Hipaa != nil {
+		pr.Hipaa = inputs.Hipaa
+	}
+}
+
+// readProject reads the project, and its settings not covered by the SDK from the same response.
+func readProject(c *apiClient, id string) (sdk.ProjectResponse, projectSettingsExt, error) {
+	var (
+		raw  json.RawMessage
+		resp sdk.ProjectResponse
+		ext  projectExt
+	)
+
+	err := c.do(http.MethodGet, "/projects/"+id, nil, &raw)
+	if err == nil {
+		err = json.Unmarshal(raw, &resp)
+	}
+	if err == nil {
+		err = json.Unmarshal(raw, &ext)
+	}
+	return resp, ext.Project.Settings, err
+}
+
+// newSDKProjectBranch defines the project's default branch for the Neon API request.
+func newSDKProjectBranch(inputs ProjectArgs) *sdk.ProjectCreateRequestProjectBranch {
+	if inputs.DefaultBranchName == nil && inputs.DefaultDatabaseName == nil && inputs.DefaultRoleName == nil {
+		return nil
+	}
+
+	return &sdk.ProjectCreateRequestProjectBranch{
+		Name:         inputs.DefaultBranchName,
+		DatabaseName: inputs.DefaultDatabaseName,
+		RoleName:     inputs.DefaultRoleName,
+	}
+}
+
+func newPgVersion(v sdk.PgVersion) *int {
+	o := int(v)
+	return &o
+}
+
+func newIntPtr(v int32) *int {
+	o := int(v)
+	return &o
+}
+
 func newHostPooler(host string) string {
 	const poolerSuffix = "-pooler"
 	els := strings.SplitN(host, ".", 2)
@@ -136,53 +891,142 @@ func (pr Project) Update(ctx context.Context, id string, olds ProjectState, news
 		return output, err
 	}
 
+	if !preview {
+		if err = updateDefaultEndpoint(ctx, c, id, news); err != nil {
+			return olds, err
+		}
+	}
+
+	// the outputs are marked unknown during preview if the inputs change, e.g. the default endpoint's host
+	// and the connection URIs are unknown until the default endpoint is re-created with the new type
 	_, _, output, err = pr.Read(ctx, id, news, olds)
 	if !preview {
 		var resp sdk.UpdateProjectRespObj
 		resp, err = c.UpdateProject(id, sdk.ProjectUpdateRequest{
 			Project: sdk.ProjectUpdateRequestProject{
-				Name: news.Name,
-				// 	TODO: add more attributes
+				Name:                    news.Name,
+				DefaultEndpointSettings: newSDKDefaultEndpointSettings(news),
+				Settings:                newSDKProjectSettings(news),
+				HistoryRetentionSeconds: newHistoryRetentionSeconds(news.HistoryRetentionSeconds),
 			},
 		})
 		if err == nil {
 			output.Name = &resp.ProjectResponse.Project.Name
+			output.setDefaultEndpointSettings(resp.ProjectResponse.Project.DefaultEndpointSettings)
+			output.setSettings(resp.ProjectResponse.Project.Settings)
+			output.HistoryRetentionSeconds = newIntPtr(resp.ProjectResponse.Project.HistoryRetentionSeconds)
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+
+		if err == nil && news.OrgID != nil && !reflect.DeepEqual(news.OrgID, output.OrgID) {
+			err = transferProject(ctx, c, id, output.OrgID, *news.OrgID)
+			if err == nil {
+				output.OrgID = news.OrgID
+			}
+		}
+
+		if err == nil && news.hasSettingsExt() {
+			err = applyProjectSettingsExt(ctx, c, id, news)
+			if err == nil {
+				output.setSettingsExt(news)
+			}
 		}
 	}
 
 	return output, err
 }
 
-func (pr Project) Read(ctx context.Context, id string, _ ProjectArgs, _ ProjectState) (
+// transferProject moves the project from the personal account, or from the org to the destination org.
+func transferProject(ctx context.Context, c *sdk.Client, id string, sourceOrgID *string, destinationOrgID string) error {
+	var err error
+	if sourceOrgID == nil {
+		_, err = c.TransferProjectsFromUserToOrg(sdk.TransferProjectsToOrganizationRequest{
+			OrgID:      destinationOrgID,
+			ProjectIDs: []string{id},
+		})
+	} else {
+		var cExt *apiClient
+		cExt, err = newAPIClient(ctx)
+		if err == nil {
+			err = cExt.do(http.MethodPost, "/organizations/"+*sourceOrgID+"/projects/transfer",
+				map[string]any{
+					"destination_org_id": destinationOrgID,
+					"project_ids":        []string{id},
+				}, nil)
+		}
+	}
+	switch {
+	case err != nil && sourceOrgID != nil:
+		return fmt.Errorf("could not transfer the project from the org %s to the org %s, "+
+			"note that the API key must belong to the user with the admin role in both orgs: %w",
+			*sourceOrgID, destinationOrgID, err)
+	case err != nil:
+		return fmt.Errorf("could not transfer the project to the org %s: %w", destinationOrgID, err)
+	}
+
+	resp, err := c.GetProject(id)
+	if err == nil && (resp.Project.OrgID == nil || *resp.Project.OrgID != destinationOrgID) {
+		err = fmt.Errorf("project %s was not transferred to the org %s", id, destinationOrgID)
+	}
+	return err
+}
+
+func (pr Project) Read(ctx context.Context, id string, inputs ProjectArgs, state ProjectState) (
 	canonicalID string, normalizedInputs ProjectArgs, normalizedState ProjectState, err error) {
 	c, err := NewSDKClient(ctx)
+
+	var cExt *apiClient
 	if err == nil {
-		var resp sdk.ProjectResponse
-		resp, err = c.GetProject(id)
+		cExt, err = newAPIClient(ctx)
+	}
+
+	if err == nil {
+		var (
+			resp        sdk.ProjectResponse
+			settingsExt projectSettingsExt
+		)
+		resp, settingsExt, err = readProject(cExt, id)
 		if err == nil {
 			canonicalID = resp.Project.ID
 			normalizedInputs.Name = &resp.Project.Name
 			normalizedInputs.OrgID = resp.Project.OrgID
-
-			var respBranches sdk.ListProjectBranchesRespObj
-			respBranches, err = c.ListProjectBranches(canonicalID, nil)
-			if err != nil {
-				return canonicalID, normalizedInputs, normalizedState, err
-			}
+			normalizedInputs.RegionID = &resp.Project.RegionID
+			normalizedInputs.PgVersion = newPgVersion(resp.Project.PgVersion)
+			normalizedInputs.setDefaultEndpointSettings(resp.Project.DefaultEndpointSettings)
+			normalizedInputs.setSettings(resp.Project.Settings)
+			normalizedInputs.HistoryRetentionSeconds = newIntPtr(resp.Project.HistoryRetentionSeconds)
+			normalizedInputs.StorePasswords = &resp.Project.StorePasswords
+			normalizedInputs.BlockPublicConnections = settingsExt.BlockPublicConnections
+			normalizedInputs.BlockVpcConnections = settingsExt.BlockVpcConnections
+			normalizedInputs.Hipaa = settingsExt.Hipaa
 
 			normalizedState = ProjectState{
 				ProjectArgs: normalizedInputs,
 				ID:          canonicalID,
 			}
 
-			var defaultBranchID string
-			for _, br := range respBranches.BranchesResponse.Branches {
-				if br.Default {
-					normalizedState.DefaultBranchName = br.Name
-					defaultBranchID = br.ID
-					break
-				}
+			var respVpcEndpoints []vpcEndpointDetails
+			respVpcEndpoints, err = listProjectVpcEndpoints(cExt, canonicalID)
+			switch {
+			case isNotFound(err) || isForbidden(err):
+				// the private networking is not available for every plan, hence no restrictions are set
+				err = nil
+			case err != nil:
+				return "", ProjectArgs{}, ProjectState{}, err
+			}
+			normalizedState.VpcEndpointRestrictions = make([]string, 0, len(respVpcEndpoints))
+			for _, ep := range respVpcEndpoints {
+				normalizedState.VpcEndpointRestrictions = append(normalizedState.VpcEndpointRestrictions,
+					ep.VpcEndpointID)
+			}
+
+			var defaultBranch sdk.Branch
+			defaultBranch, err = readDefaultBranch(c, canonicalID)
+			if err != nil {
+				return "", ProjectArgs{}, ProjectState{}, err
 			}
+			normalizedState.DefaultBranchName = defaultBranch.Name
+			defaultBranchID := defaultBranch.ID
 
 			var respDB sdk.DatabasesResponse
 			respDB, err = c.ListProjectBranchDatabases(canonicalID, defaultBranchID)
@@ -190,50 +1034,160 @@ func (pr Project) Read(ctx context.Context, id string, _ ProjectArgs, _ ProjectS
 				return "", ProjectArgs{}, ProjectState{}, err
 			}
 
-			slices.SortStableFunc(respDB.Databases, func(a, b sdk.Database) int {
-				return a.CreatedAt.Compare(b.CreatedAt)
-			})
+			defaultDatabaseName := inputs.DefaultDatabaseName
+			if state.DefaultDatabaseName != "" {
+				defaultDatabaseName = &state.DefaultDatabaseName
+			}
+			defaultDatabase := findDefaultDatabase(respDB.Databases, defaultDatabaseName)
+			normalizedState.DefaultDatabaseName = defaultDatabase.Name
+			normalizedState.DefaultRoleName = defaultDatabase.OwnerName
 
-			// the earliest created database is assumed default
-			earliestCreatedDatabase := respDB.Databases[0]
-			normalizedState.DefaultDatabaseName = earliestCreatedDatabase.Name
-			normalizedState.DefaultRoleName = earliestCreatedDatabase.OwnerName
+			normalizedInputs.DefaultBranchName = &normalizedState.DefaultBranchName
+			normalizedInputs.DefaultDatabaseName = &normalizedState.DefaultDatabaseName
+			normalizedInputs.DefaultRoleName = &normalizedState.DefaultRoleName
 
-			var respPass sdk.RolePasswordResponse
-			respPass, err = c.GetProjectBranchRolePassword(canonicalID, defaultBranchID, earliestCreatedDatabase.OwnerName)
+			var defaultEndpoint sdk.Endpoint
+			defaultEndpoint, err = readDefaultEndpoint(c, canonicalID, defaultBranchID)
 			if err != nil {
 				return "", ProjectArgs{}, ProjectState{}, err
 			}
+			normalizedState.DefaultEndpointType = newEndpointType(defaultEndpoint.Type)
+			normalizedInputs.DefaultEndpointType = normalizedState.DefaultEndpointType
+			normalizedState.Provisioner = newProvisioner(defaultEndpoint.Provisioner)
+			normalizedInputs.Provisioner = normalizedState.Provisioner
+			normalizedState.DefaultEndpointHost = defaultEndpoint.Host
+			normalizedState.DefaultEndpointHostPooler = newHostPooler(defaultEndpoint.Host)
 
-			normalizedState.DefaultRolePassword = respPass.Password
-
-			var respEndpoints sdk.EndpointsResponse
-			respEndpoints, err = c.ListProjectBranchEndpoints(canonicalID, defaultBranchID)
+			normalizedState.DefaultRolePassword, normalizedState.ConnectionURI, err = readDefaultConnection(c,
+				canonicalID, defaultBranchID, defaultEndpoint, defaultDatabase, resp.Project.StorePasswords)
 			if err != nil {
 				return "", ProjectArgs{}, ProjectState{}, err
 			}
-			// the earliest created endpoint is assumed default
-			slices.SortStableFunc(respEndpoints.Endpoints, func(a, b sdk.Endpoint) int {
-				return a.CreatedAt.Compare(b.CreatedAt)
-			})
+			normalizedState.ConnectionURIPooler = newURIPooler(normalizedState.ConnectionURI)
+		}
+	}
 
-			earliestCreatedEndpoint := respEndpoints.Endpoints[0]
-			normalizedState.DefaultEndpointHost = earliestCreatedEndpoint.Host
-			normalizedState.DefaultEndpointHostPooler = newHostPooler(earliestCreatedEndpoint.Host)
+	return canonicalID, normalizedInputs, normalizedState, err
+}
 
-			var respURI sdk.ConnectionURIResponse
-			pooled := false
-			respURI, err = c.GetConnectionURI(canonicalID, &defaultBranchID, &earliestCreatedEndpoint.ID,
-				earliestCreatedDatabase.Name, earliestCreatedDatabase.OwnerName, &pooled)
-			if err != nil {
-				return "", ProjectArgs{}, ProjectState{}, err
+// readDefaultConnection reads the password of the database owner, and the URI to connect to the database
+// using the endpoint. The password cannot be revealed if the project does not store the passwords,
+// hence it is left empty, and the URI is built without it.
+func readDefaultConnection(c *sdk.Client, projectID, branchID string, endpoint sdk.Endpoint, db sdk.Database,
+	storePasswords bool) (password, uri string, err error) {
+	if !storePasswords {
+		u := url.URL{
+			Scheme:   "postgresql",
+			User:     url.User(db.OwnerName),
+			Host:     endpoint.Host,
+			Path:     "/" + db.Name,
+			RawQuery: "sslmode=require",
+		}
+		return "", u.String(), nil
+	}
+
+	respPass, err := c.GetProjectBranchRolePassword(projectID, branchID, db.OwnerName)
+	if err != nil {
+		return "", "", err
+	}
+
+	pooled := false
+	respURI, err := c.GetConnectionURI(projectID, &branchID, &endpoint.ID, db.Name, db.OwnerName, &pooled)
+	if err != nil {
+		return "", "", err
+	}
+
+	return respPass.Password, respURI.URI, nil
+}
+
+// readDefaultBranch reads the project's default branch.
+func readDefaultBranch(c *sdk.Client, projectID string) (sdk.Branch, error) {
+	resp, err := c.ListProjectBranches(projectID, nil)
+	if err != nil {
+		return sdk.Branch{}, err
+	}
+
+	for _, br := range resp.BranchesResponse.Branches {
+		if br.Default {
+			return br, nil
+		}
+	}
+
+	return sdk.Branch{}, fmt.Errorf("default branch not found in the project %s", projectID)
+}
+
+// readDefaultEndpoint reads the endpoint of the project's default branch.
+// The earliest created endpoint is assumed default.
+func readDefaultEndpoint(c *sdk.Client, projectID, branchID string) (sdk.Endpoint, error) {
+	resp, err := c.ListProjectBranchEndpoints(projectID, branchID)
+	if err != nil {
+		return sdk.Endpoint{}, err
+	}
+
+	if len(resp.Endpoints) == 0 {
+		return sdk.Endpoint{}, fmt.Errorf("no endpoint found on the default branch of the project %s", projectID)
+	}
+
+	return slices.MinFunc(resp.Endpoints, func(a, b sdk.Endpoint) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}), nil
+}
+
+// updateDefaultEndpoint applies the type and the provisioner to the project's default endpoint.
+// The endpoint is re-created if its type changes, its provisioner is changed in place otherwise.
+func updateDefaultEndpoint(ctx context.Context, c *sdk.Client, projectID string, inputs ProjectArgs) error {
+	if inputs.Provisioner == nil && inputs.DefaultEndpointType == nil {
+		return nil
+	}
+
+	br, err := readDefaultBranch(c, projectID)
+	if err != nil {
+		return err
+	}
+
+	ep, err := readDefaultEndpoint(c, projectID, br.ID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case optionalChanged(newEndpointType(ep.Type), inputs.DefaultEndpointType):
+		// the endpoint keeps its provisioner unless the new one is set
+		if inputs.Provisioner == nil {
+			inputs.Provisioner = newProvisioner(ep.Provisioner)
+		}
+		_, err = replaceDefaultEndpoint(ctx, c, projectID, br.ID, ep.ID, inputs)
+
+	case optionalChanged(newProvisioner(ep.Provisioner), inputs.Provisioner):
+		var resp sdk.EndpointOperations
+		resp, err = c.UpdateProjectEndpoint(projectID, ep.ID, sdk.EndpointUpdateRequest{
+			Endpoint: sdk.EndpointUpdateRequestEndpoint{Provisioner: newSDKProvisioner(inputs.Provisioner)},
+		})
+		if err == nil {
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not update the default endpoint: %w", err)
+	}
+	return nil
+}
+
+// findDefaultDatabase finds the project's default database by its name.
+// The earliest created database is assumed default if the name is not known, e.g. when the project is imported.
+func findDefaultDatabase(databases []sdk.Database, name *string) sdk.Database {
+	if name != nil {
+		for _, db := range databases {
+			if db.Name == *name {
+				return db
 			}
-			normalizedState.ConnectionURI = respURI.URI
-			normalizedState.ConnectionURIPooler = newURIPooler(respURI.URI)
 		}
 	}
 
-	return canonicalID, normalizedInputs, normalizedState, err
+	return slices.MinFunc(databases, func(a, b sdk.Database) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
 }
 
 func (pr Project) Delete(ctx context.Context, id string, _ ProjectState) error {
@@ -254,6 +1208,10 @@ func (pr Project) Diff(ctx context.Context, id string, olds ProjectState, news P
 	// define the deviation of the SaaS state from the pulumi state
 	drift := projectDrift(stateCloud, olds)
 
+	if err = validateIrreversibleChange(args, news); err != nil {
+		return diff, err
+	}
+
 	// define the change between the old and the new inputs
 	inputChange := projectInputChange(args, news)
 
@@ -264,9 +1222,57 @@ func (pr Project) Diff(ctx context.Context, id string, olds ProjectState, news P
 	}
 	maps.Copy(o.DetailedDiff, inputChange.DetailedDiff)
 
+	_, ok := inputChange.DetailedDiff["enable_logical_replication"]
+	if ok && news.EnableLogicalReplication != nil && *news.EnableLogicalReplication {
+		p.GetLogger(ctx).Warning("logical replication will be enabled: it cannot be disabled afterwards, " +
+			"and all active endpoints of the project will be restarted")
+	}
+
+	_, ok = inputChange.DetailedDiff["hipaa"]
+	if ok && news.Hipaa != nil && *news.Hipaa {
+		p.GetLogger(ctx).Warning("HIPAA compliance will be enabled: it cannot be disabled afterwards")
+	}
+
+	for _, k := range []string{
+		"store_passwords", "default_branch_name", "default_database_name", "default_role_name",
+	} {
+		if _, ok := inputChange.DetailedDiff[k]; ok {
+			p.GetLogger(ctx).Warningf("%s cannot be changed for the existing project, "+
+				"hence it will be replaced: a new project will be created, and all its data will be lost", k)
+		}
+	}
+
+	if _, ok := inputChange.DetailedDiff["default_endpoint_type"]; ok {
+		p.GetLogger(ctx).Warning("the default endpoint will be re-created because its type changes: " +
+			"its host and the connection URIs will change, and its active connections will be terminated")
+	}
+
+	if inputChange.DetailedDiff["org_id"].Kind == p.UpdateReplace {
+		p.GetLogger(ctx).Warning("the project cannot be transferred from the org to the personal account, " +
+			"hence it will be replaced: a new project will be created, and all its data will be lost")
+	}
+
 	return o, err
 }
 
+// validateIrreversibleChange checks that the settings which cannot be reverted are not disabled once enabled.
+func validateIrreversibleChange(olds ProjectArgs, news ProjectArgs) error {
+	var errs []error
+	for _, setting := range []struct {
+		name       string
+		olds, news *bool
+	}{
+		{name: "enable_logical_replication", olds: olds.EnableLogicalReplication, news: news.EnableLogicalReplication},
+		{name: "hipaa", olds: olds.Hipaa, news: news.Hipaa},
+	} {
+		if setting.olds != nil && *setting.olds && setting.news != nil && !*setting.news {
+			errs = append(errs, fmt.Errorf("%s is enabled for the project and cannot be disabled, "+
+				"please set it to true, or remove it from the manifest", setting.name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func projectInputChange(olds ProjectArgs, news ProjectArgs) p.DiffResponse {
 	var o = p.DiffResponse{
 		DeleteBeforeReplace: false,
@@ -290,14 +1296,132 @@ func projectInputChange(olds ProjectArgs, news ProjectArgs) p.DiffResponse {
 		}
 	}
 
-	// the project should be moved to the org id the new input differs from the old pulumi input
-	var changedOrgID = !reflect.DeepEqual(news.OrgID, olds.OrgID)
-	if changedOrgID {
+	// the project must be re-created to be moved to another region, or to upgrade postgres
+	for k, changed := range map[string]bool{
+		"region_id":  optionalChanged(olds.RegionID, news.RegionID),
+		"pg_version": optionalChanged(olds.PgVersion, news.PgVersion),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.DeleteReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	if olds.DefaultEndpointSettings.changed(news.DefaultEndpointSettings) {
+		o.HasChanges = true
+		o.DetailedDiff["default_endpoint_settings"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	if olds.Quota.changed(news.Quota) {
+		o.HasChanges = true
+		o.DetailedDiff["quota"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	if olds.MaintenanceWindow.changed(news.MaintenanceWindow) {
+		o.HasChanges = true
+		o.DetailedDiff["maintenance_window"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	// the settings are compared key by key to show the change of every setting
+	maps.Copy(o.DetailedDiff, settingsChange("pg_settings", olds.PgSettings, news.PgSettings, true))
+	maps.Copy(o.DetailedDiff, settingsChange("pgbouncer_settings", olds.PgbouncerSettings, news.PgbouncerSettings,
+		true))
+	if len(o.DetailedDiff) > 0 {
+		o.HasChanges = true
+	}
+
+	if optionalChanged(olds.BlockPublicConnections, news.BlockPublicConnections) {
+		o.HasChanges = true
+		o.DetailedDiff["block_public_connections"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	// the order of the allowed IPs is irrelevant
+	if news.AllowedIps != nil && !equalUnordered(olds.AllowedIps, news.AllowedIps) {
+		o.HasChanges = true
+		o.DetailedDiff["allowed_ips"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	for k, changed := range map[string]bool{
+		"protected_branches_only":    optionalChanged(olds.ProtectedBranchesOnly, news.ProtectedBranchesOnly),
+		"history_retention_seconds":  optionalChanged(olds.HistoryRetentionSeconds, news.HistoryRetentionSeconds),
+		"enable_logical_replication": optionalChanged(olds.EnableLogicalReplication, news.EnableLogicalReplication),
+		"block_vpc_connections":      optionalChanged(olds.BlockVpcConnections, news.BlockVpcConnections),
+		"hipaa":                      optionalChanged(olds.Hipaa, news.Hipaa),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.Update,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the compute provisioner and the type are changed for the default endpoint
+	for k, changed := range map[string]bool{
+		"provisioner":           optionalChanged(olds.Provisioner, news.Provisioner),
+		"default_endpoint_type": optionalChanged(olds.DefaultEndpointType, news.DefaultEndpointType),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.Update,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the passwords storage, the default branch, database and role cannot be changed for the existing project
+	for k, changed := range map[string]bool{
+		"store_passwords":       optionalChanged(olds.StorePasswords, news.StorePasswords),
+		"default_branch_name":   optionalChanged(olds.DefaultBranchName, news.DefaultBranchName),
+		"default_database_name": optionalChanged(olds.DefaultDatabaseName, news.DefaultDatabaseName),
+		"default_role_name":     optionalChanged(olds.DefaultRoleName, news.DefaultRoleName),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the project should be moved to the org if the new input differs from the old pulumi input
+	if !reflect.DeepEqual(news.OrgID, olds.OrgID) {
 		o.HasChanges = true
 		o.DetailedDiff["org_id"] = p.PropertyDiff{
 			Kind:      p.Update,
 			InputDiff: true,
 		}
+
+		// the project cannot be transferred from the org to the personal account
+		if news.OrgID == nil {
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff["org_id"] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
 	}
 
 	return o
@@ -367,5 +1491,79 @@ func projectDrift(read ProjectState, pulumi ProjectState) p.DiffResponse {
 		}
 	}
 
+	if read.DefaultEndpointSettings.changed(pulumi.DefaultEndpointSettings) {
+		o.HasChanges = true
+		o.DetailedDiff["default_endpoint_settings"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: false,
+		}
+	}
+
+	if read.Quota.changed(pulumi.Quota) {
+		o.HasChanges = true
+		o.DetailedDiff["quota"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: false,
+		}
+	}
+
+	if read.MaintenanceWindow.changed(pulumi.MaintenanceWindow) {
+		o.HasChanges = true
+		o.DetailedDiff["maintenance_window"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: false,
+		}
+	}
+
+	maps.Copy(o.DetailedDiff, settingsChange("pg_settings", read.PgSettings, pulumi.PgSettings, false))
+	maps.Copy(o.DetailedDiff, settingsChange("pgbouncer_settings", read.PgbouncerSettings, pulumi.PgbouncerSettings,
+		false))
+	if len(o.DetailedDiff) > 0 {
+		o.HasChanges = true
+	}
+
+	if optionalChanged(read.BlockPublicConnections, pulumi.BlockPublicConnections) {
+		o.HasChanges = true
+		o.DetailedDiff["block_public_connections"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: false,
+		}
+	}
+
+	if pulumi.AllowedIps != nil && !equalUnordered(read.AllowedIps, pulumi.AllowedIps) {
+		o.HasChanges = true
+		o.DetailedDiff["allowed_ips"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: false,
+		}
+	}
+
+	for k, changed := range map[string]bool{
+		"protected_branches_only":    optionalChanged(read.ProtectedBranchesOnly, pulumi.ProtectedBranchesOnly),
+		"history_retention_seconds":  optionalChanged(read.HistoryRetentionSeconds, pulumi.HistoryRetentionSeconds),
+		"enable_logical_replication": optionalChanged(read.EnableLogicalReplication, pulumi.EnableLogicalReplication),
+		"block_vpc_connections":      optionalChanged(read.BlockVpcConnections, pulumi.BlockVpcConnections),
+		"hipaa":                      optionalChanged(read.Hipaa, pulumi.Hipaa),
+		"provisioner":                optionalChanged(read.Provisioner, pulumi.Provisioner),
+		"default_endpoint_type":      optionalChanged(read.DefaultEndpointType, pulumi.DefaultEndpointType),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.Update,
+				InputDiff: false,
+			}
+		}
+	}
+
+	// the passwords storage cannot be changed for the existing project
+	if optionalChanged(read.StorePasswords, pulumi.StorePasswords) {
+		o.HasChanges = true
+		o.DetailedDiff["store_passwords"] = p.PropertyDiff{
+			Kind:      p.UpdateReplace,
+			InputDiff: false,
+		}
+	}
+
 	return o
 }
@@ -0,0 +1,159 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type ProjectPermission struct{}
+
+type ProjectPermissionArgs struct {
+	ProjectID string `pulumi:"project_id"`
+	Grantee   string `pulumi:"grantee"`
+}
+
+func (pp *ProjectPermissionArgs) Annotate(a infer.Annotator) {
+	a.Describe(&pp.ProjectID, "Neon project ID.")
+	a.Describe(&pp.Grantee, "Email of the user to share the project with.")
+}
+
+type ProjectPermissionState struct {
+	ProjectPermissionArgs
+	ID           string `pulumi:"identifier"`
+	PermissionID string `pulumi:"permission_id"`
+	GrantedAt    string `pulumi:"granted_at"`
+}
+
+func (pp *ProjectPermissionState) Annotate(a infer.Annotator) {
+	a.Describe(&pp.ID, "Permission identifier in the format project_id/permission_id.")
+	a.Describe(&pp.PermissionID, "Permission ID.")
+	a.Describe(&pp.GrantedAt, "Timestamp when the access was granted.")
+}
+
+func (pp ProjectPermission) Create(ctx context.Context, _ string, inputs ProjectPermissionArgs, preview bool) (
+	id string, output ProjectPermissionState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		var resp sdk.ProjectPermission
+		resp, err = c.GrantPermissionToProject(inputs.ProjectID, sdk.GrantPermissionToProjectRequest{
+			Email: inputs.Grantee,
+		})
+		if err != nil {
+			return id, output, err
+		}
+
+		output = newProjectPermissionState(inputs.ProjectID, resp)
+		id = output.ID
+	}
+
+	return id, output, err
+}
+
+func newProjectPermissionState(projectID string, v sdk.ProjectPermission) ProjectPermissionState {
+	return ProjectPermissionState{
+		ProjectPermissionArgs: ProjectPermissionArgs{
+			ProjectID: projectID,
+			Grantee:   v.GrantedToEmail,
+		},
+		ID:           newCompositeID(projectID, v.ID),
+		PermissionID: v.ID,
+		GrantedAt:    v.GrantedAt.Format(time.RFC3339),
+	}
+}
+
+func (pp ProjectPermission) Read(ctx context.Context, id string, inputs ProjectPermissionArgs,
+	state ProjectPermissionState) (
+	canonicalID string, normalizedInputs ProjectPermissionArgs, normalizedState ProjectPermissionState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var ids []string
+		ids, err = parseCompositeID(id, 2)
+		if err != nil {
+			return "", inputs, state, err
+		}
+		projectID, permissionID := ids[0], ids[1]
+
+		var resp sdk.ProjectPermissions
+		resp, err = c.ListProjectPermissions(projectID)
+		switch {
+		case isNotFound(err):
+			// the project was deleted
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		// the permission is removed from the state if the access was revoked outside of pulumi
+		for _, v := range resp.ProjectPermissions {
+			if v.ID == permissionID && v.RevokedAt == nil {
+				normalizedState = newProjectPermissionState(projectID, v)
+				// the email is case-insensitive
+				if strings.EqualFold(state.Grantee, v.GrantedToEmail) {
+					normalizedState.Grantee = state.Grantee
+				}
+				normalizedInputs = normalizedState.ProjectPermissionArgs
+				canonicalID = normalizedState.ID
+				break
+			}
+		}
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (pp ProjectPermission) Delete(ctx context.Context, _ string, props ProjectPermissionState) error {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		_, err = c.RevokePermissionFromProject(props.ProjectID, props.PermissionID)
+		if isNotFound(err) {
+			err = nil
+		}
+	}
+	return err
+}
+
+func (pp ProjectPermission) Diff(_ context.Context, _ string, olds ProjectPermissionState,
+	news ProjectPermissionArgs) (p.DiffResponse, error) {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the permission cannot be changed, it can only be granted anew
+	for k, changed := range map[string]bool{
+		"project_id": news.ProjectID != olds.ProjectID,
+		"grantee":    !strings.EqualFold(news.Grantee, olds.Grantee),
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectPermission_Diff(t *testing.T) {
+	olds := ProjectPermissionState{
+		ProjectPermissionArgs: ProjectPermissionArgs{ProjectID: "foo", Grantee: "foo@bar.baz"},
+		ID:                    "foo/1",
+		PermissionID:          "1",
+	}
+
+	tests := []struct {
+		name string
+		news ProjectPermissionArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			news: ProjectPermissionArgs{ProjectID: "foo", Grantee: "foo@bar.baz"},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "grantee case changed",
+			news: ProjectPermissionArgs{ProjectID: "foo", Grantee: "Foo@Bar.baz"},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "grantee changed",
+			news: ProjectPermissionArgs{ProjectID: "foo", Grantee: "qux@bar.baz"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"grantee": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "project changed",
+			news: ProjectPermissionArgs{ProjectID: "bar", Grantee: "foo@bar.baz"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"project_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProjectPermission{}.Diff(context.Background(), olds.ID, olds, tt.news)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProjectPermission_Read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/foo/permissions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"project_permissions":[
+{"id":"1","granted_to_email":"foo@bar.baz","granted_at":"2024-01-01T00:00:00Z"},
+{"id":"2","granted_to_email":"bar@bar.baz","granted_at":"2024-01-01T00:00:00Z",
+"revoked_at":"2024-01-02T00:00:00Z"}
+]}`))
+	})
+	mux.HandleFunc("GET /projects/bar/permissions", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"","message":"project not found"}`))
+	})
+	s := newTestServer(t, mux)
+
+	tests := []struct {
+		name        string
+		id          string
+		grantee     string
+		wantID      string
+		wantGrantee string
+	}{
+		{
+			name:        "granted",
+			id:          "foo/1",
+			grantee:     "Foo@Bar.baz",
+			wantID:      "foo/1",
+			wantGrantee: "Foo@Bar.baz",
+		},
+		{
+			name:    "revoked",
+			id:      "foo/2",
+			grantee: "bar@bar.baz",
+			wantID:  "",
+		},
+		{
+			name:    "not listed",
+			id:      "foo/3",
+			grantee: "qux@bar.baz",
+			wantID:  "",
+		},
+		{
+			name:    "project deleted",
+			id:      "bar/1",
+			grantee: "foo@bar.baz",
+			wantID:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Read(p.ReadRequest{
+				ID:  tt.id,
+				Urn: newTestURN("ProjectPermission"),
+				Properties: resource.PropertyMap{
+					"project_id": resource.NewStringProperty("foo"),
+					"grantee":    resource.NewStringProperty(tt.grantee),
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.ID)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantGrantee, got.Properties["grantee"].StringValue())
+			}
+		})
+	}
+}
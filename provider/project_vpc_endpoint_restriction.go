@@ -0,0 +1,191 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type ProjectVpcEndpointRestriction struct{}
+
+type ProjectVpcEndpointRestrictionArgs struct {
+	ProjectID     string `pulumi:"project_id"`
+	VpcEndpointID string `pulumi:"vpc_endpoint_id"`
+	Label         string `pulumi:"label"`
+}
+
+func (v *ProjectVpcEndpointRestrictionArgs) Annotate(a infer.Annotator) {
+	a.Describe(&v.ProjectID, "Neon project ID.")
+	a.Describe(&v.VpcEndpointID,
+		"ID of the VPC endpoint registered in the project's Org, e.g. vpce-1234567890abcdef0.")
+	a.Describe(&v.Label, "Descriptive label of the restriction.")
+}
+
+type ProjectVpcEndpointRestrictionState struct {
+	ProjectVpcEndpointRestrictionArgs
+	ID string `pulumi:"identifier"`
+}
+
+func (v *ProjectVpcEndpointRestrictionState) Annotate(a infer.Annotator) {
+	a.Describe(&v.ID, "Restriction identifier in the format project_id/vpc_endpoint_id.")
+}
+
+// vpcEndpoints the list of VPC endpoints as returned by the Neon API.
+type vpcEndpoints struct {
+	Endpoints []vpcEndpointDetails `json:"endpoints"`
+}
+
+func projectVpcEndpointsPath(projectID string) string {
+	return "/projects/" + projectID + "/vpc_endpoints"
+}
+
+// listProjectVpcEndpoints lists the VPC endpoints the project is restricted to.
+func listProjectVpcEndpoints(c *apiClient, projectID string) ([]vpcEndpointDetails, error) {
+	var resp vpcEndpoints
+	err := c.do(http.MethodGet, projectVpcEndpointsPath(projectID), nil, &resp)
+	return resp.Endpoints, err
+}
+
+func (v ProjectVpcEndpointRestriction) Create(ctx context.Context, _ string, inputs ProjectVpcEndpointRestrictionArgs,
+	preview bool) (id string, output ProjectVpcEndpointRestrictionState, err error) {
+	c, err := newAPIClient(ctx)
+
+	if !preview && err == nil {
+		err = c.do(http.MethodPost, projectVpcEndpointsPath(inputs.ProjectID)+"/"+inputs.VpcEndpointID,
+			vpcEndpointAssignment{Label: inputs.Label}, nil)
+		if err != nil {
+			return id, output, err
+		}
+
+		id = newCompositeID(inputs.ProjectID, inputs.VpcEndpointID)
+		output = ProjectVpcEndpointRestrictionState{
+			ProjectVpcEndpointRestrictionArgs: inputs,
+			ID:                                id,
+		}
+	}
+
+	return id, output, err
+}
+
+func (v ProjectVpcEndpointRestriction) Update(ctx context.Context, _ string, olds ProjectVpcEndpointRestrictionState,
+	news ProjectVpcEndpointRestrictionArgs, preview bool) (output ProjectVpcEndpointRestrictionState, err error) {
+	c, err := newAPIClient(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output = olds
+	output.ProjectVpcEndpointRestrictionArgs = news
+	if !preview {
+		// the label is updated by assigning the endpoint anew
+		err = c.do(http.MethodPost, projectVpcEndpointsPath(news.ProjectID)+"/"+news.VpcEndpointID,
+			vpcEndpointAssignment{Label: news.Label}, nil)
+	}
+
+	return output, err
+}
+
+func (v ProjectVpcEndpointRestriction) Read(ctx context.Context, id string, inputs ProjectVpcEndpointRestrictionArgs,
+	state ProjectVpcEndpointRestrictionState) (canonicalID string, normalizedInputs ProjectVpcEndpointRestrictionArgs,
+	normalizedState ProjectVpcEndpointRestrictionState, err error) {
+	c, err := newAPIClient(ctx)
+	if err == nil {
+		var ids []string
+		ids, err = parseCompositeID(id, 2)
+		if err != nil {
+			return "", inputs, state, err
+		}
+		projectID, vpcEndpointID := ids[0], ids[1]
+
+		var resp []vpcEndpointDetails
+		resp, err = listProjectVpcEndpoints(c, projectID)
+		switch {
+		case isNotFound(err):
+			// the project was deleted
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		// the restriction is removed from the state if it was removed outside of pulumi
+		for _, ep := range resp {
+			if ep.VpcEndpointID == vpcEndpointID {
+				canonicalID = id
+				normalizedState = ProjectVpcEndpointRestrictionState{
+					ProjectVpcEndpointRestrictionArgs: ProjectVpcEndpointRestrictionArgs{
+						ProjectID:     projectID,
+						VpcEndpointID: vpcEndpointID,
+						Label:         ep.Label,
+					},
+					ID: id,
+				}
+				normalizedInputs = normalizedState.ProjectVpcEndpointRestrictionArgs
+				break
+			}
+		}
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+func (v ProjectVpcEndpointRestriction) Delete(ctx context.Context, _ string,
+	props ProjectVpcEndpointRestrictionState) error {
+	c, err := newAPIClient(ctx)
+	if err == nil {
+		err = c.do(http.MethodDelete, projectVpcEndpointsPath(props.ProjectID)+"/"+props.VpcEndpointID, nil, nil)
+		if isNotFound(err) {
+			err = nil
+		}
+	}
+	return err
+}
+
+func (v ProjectVpcEndpointRestriction) Diff(_ context.Context, _ string, olds ProjectVpcEndpointRestrictionState,
+	news ProjectVpcEndpointRestrictionArgs) (p.DiffResponse, error) {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	for k, changed := range map[string]bool{
+		"project_id":      news.ProjectID != olds.ProjectID,
+		"vpc_endpoint_id": news.VpcEndpointID != olds.VpcEndpointID,
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the label is updated in place
+	if news.Label != olds.Label {
+		o.HasChanges = true
+		o.DetailedDiff["label"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectVpcEndpointRestriction_Read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/foo/vpc_endpoints", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"endpoints":[{"vpc_endpoint_id":"vpce-foo","label":"bar"}]}`))
+	})
+	s := newTestServer(t, mux)
+
+	tests := []struct {
+		name      string
+		id        string
+		wantID    string
+		wantLabel string
+	}{
+		{
+			name:      "restricted",
+			id:        "foo/vpce-foo",
+			wantID:    "foo/vpce-foo",
+			wantLabel: "bar",
+		},
+		{
+			name:   "restriction removed outside of pulumi",
+			id:     "foo/vpce-bar",
+			wantID: "",
+		},
+		{
+			name:   "project deleted",
+			id:     "bar/vpce-foo",
+			wantID: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Read(p.ReadRequest{
+				ID:  tt.id,
+				Urn: newTestURN("ProjectVpcEndpointRestriction"),
+				Properties: resource.PropertyMap{
+					"project_id":      resource.NewStringProperty("foo"),
+					"vpc_endpoint_id": resource.NewStringProperty("vpce-foo"),
+					"label":           resource.NewStringProperty("foo"),
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.ID)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantLabel, got.Properties["label"].StringValue())
+			}
+		})
+	}
+}
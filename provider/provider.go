@@ -17,7 +17,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	sdk "github.com/kislerdm/neon-sdk-go"
 	"github.com/kislerdm/pulumi-neon/provider/telemetry"
@@ -30,6 +35,7 @@ import (
 	nodejsGen "github.com/pulumi/pulumi/pkg/v3/codegen/nodejs"
 	pythonGen "github.com/pulumi/pulumi/pkg/v3/codegen/python"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 )
 
 var Version = "testAcc"
@@ -94,6 +100,16 @@ func Provider() p.Provider {
 		},
 		Resources: []infer.InferredResource{
 			infer.Resource[Project, ProjectArgs, ProjectState](),
+			infer.Resource[Branch, BranchArgs, BranchState](),
+			infer.Resource[Endpoint, EndpointArgs, EndpointState](),
+			infer.Resource[Role, RoleArgs, RoleState](),
+			infer.Resource[Database, DatabaseArgs, DatabaseState](),
+			infer.Resource[ApiKey, ApiKeyArgs, ApiKeyState](),
+			infer.Resource[ProjectPermission, ProjectPermissionArgs, ProjectPermissionState](),
+			infer.Resource[OrgVpcEndpoint, OrgVpcEndpointArgs, OrgVpcEndpointState](),
+			infer.Resource[ProjectVpcEndpointRestriction, ProjectVpcEndpointRestrictionArgs,
+				ProjectVpcEndpointRestrictionState](),
+			infer.Resource[Jwks, JwksArgs, JwksState](),
 		},
 		Config: infer.Config[*Config](),
 		ModuleMap: map[tokens.ModuleName]tokens.ModuleName{
@@ -105,21 +121,125 @@ func Provider() p.Provider {
 }
 
 type Config struct {
-	APIKey string `pulumi:"api_key"`
+	APIKey      string `pulumi:"api_key"`
+	APIEndpoint string `pulumi:"api_endpoint,optional"`
+	MaxRetries  *int   `pulumi:"max_retries,optional"`
+	MaxBackoff  string `pulumi:"max_backoff,optional"`
 }
 
 func (c *Config) Annotate(a infer.Annotator) {
 	a.Describe(&c.APIKey, "Neon API token.")
 	a.SetDefault(&c.APIKey, nil, "NEON_API_KEY")
+	a.Describe(&c.APIEndpoint, "Neon API base URL, e.g. the URL of the API gateway. "+
+		"The public Neon API endpoint "+defaultAPIEndpoint+" is used if not set.")
+	a.SetDefault(&c.APIEndpoint, nil, "NEON_API_ENDPOINT")
+	a.Describe(&c.MaxRetries, fmt.Sprintf("Maximum number of retries of the Neon API request "+
+		"which failed because the project was locked by a running operation, the rate limit was exceeded, "+
+		"or because of the server error. Defaults to %d, 0 disables the retries.", telemetry.DefaultMaxRetries))
+	a.Describe(&c.MaxBackoff, fmt.Sprintf("Maximum duration to wait before the failed Neon API request is retried, "+
+		"e.g. 30s, or 1m. Defaults to %s. The wait requested by the Neon API is not limited by it, "+
+		"but the request fails without retries if the Neon API requests to wait longer than %s, "+
+		"or past the resource's timeout.",
+		telemetry.DefaultMaxBackoff, telemetry.DefaultMaxRetryAfter))
 }
 
 func NewSDKClient(ctx context.Context) (*sdk.Client, error) {
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not init Neon Client: %w", err)
+	}
+
 	c, err := sdk.NewClient(sdk.Config{
 		Key:        infer.GetConfig[*Config](ctx).APIKey,
-		HTTPClient: telemetry.NewHTTPClient("kislerdm/"+Name, Version),
+		HTTPClient: httpClient,
 	})
 	if err != nil {
 		err = fmt.Errorf("could not init Neon Client: %w", err)
 	}
 	return c, err
 }
+
+func newHTTPClient(ctx context.Context) (sdk.HTTPClient, error) {
+	cfg := infer.GetConfig[*Config](ctx)
+
+	// the SDK does not accept the context, hence it is bound to the client to honor cancellation and customTimeouts
+	telemetryClient := telemetry.NewHTTPClient("kislerdm/"+Name, Version).WithContext(ctx)
+	if err := setRetryPolicy(telemetryClient, cfg); err != nil {
+		return nil, err
+	}
+	telemetryClient.Logger = p.GetLogger(ctx)
+	// the headers and the bodies of the API calls are logged only if the provider runs with -v=9
+	telemetryClient.Verbose = bool(logging.V(9))
+
+	var c sdk.HTTPClient = telemetryClient
+
+	endpoint, err := newAPIEndpoint(cfg.APIEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// the SDK always targets the public Neon API, hence its requests are routed to the configured endpoint
+	if endpoint != defaultAPIEndpoint {
+		c = endpointHTTPClient{endpoint: endpoint, c: c}
+	}
+
+	return c, nil
+}
+
+// setRetryPolicy sets the retry policy of the HTTP client defined by the provider's config.
+func setRetryPolicy(c *telemetry.HTTPClient, cfg *Config) error {
+	if cfg.MaxRetries != nil {
+		if *cfg.MaxRetries < 0 {
+			return fmt.Errorf("max_retries must not be negative, %d provided", *cfg.MaxRetries)
+		}
+		c.MaxRetries = *cfg.MaxRetries
+	}
+
+	if cfg.MaxBackoff != "" {
+		d, err := time.ParseDuration(cfg.MaxBackoff)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("max_backoff %q is not a valid positive duration, e.g. 30s, or 1m", cfg.MaxBackoff)
+		}
+		c.MaxBackoff = d
+	}
+
+	return nil
+}
+
+// isNotFound checks if the error was returned by the Neon API because the requested object does not exist.
+func isNotFound(err error) bool {
+	var sdkErr sdk.Error
+	if errors.As(err, &sdkErr) {
+		return sdkErr.HTTPCode == http.StatusNotFound
+	}
+
+	var apiErr apiError
+	return errors.As(err, &apiErr) && apiErr.HTTPCode == http.StatusNotFound
+}
+
+func isForbidden(err error) bool {
+	var sdkErr sdk.Error
+	if errors.As(err, &sdkErr) {
+		return sdkErr.HTTPCode == http.StatusForbidden
+	}
+
+	var apiErr apiError
+	return errors.As(err, &apiErr) && apiErr.HTTPCode == http.StatusForbidden
+}
+
+const compositeIDSeparator = "/"
+
+// newCompositeID defines the ID of the resource which is identified by several attributes.
+func newCompositeID(parts ...string) string {
+	return strings.Join(parts, compositeIDSeparator)
+}
+
+// parseCompositeID splits the ID generated by newCompositeID.
+func parseCompositeID(id string, n int) ([]string, error) {
+	o := strings.Split(id, compositeIDSeparator)
+	if len(o) != n || slices.Contains(o, "") {
+		return nil, fmt.Errorf("ID %s is invalid, %d elements separated by '%s' expected", id, n,
+			compositeIDSeparator)
+	}
+	return o, nil
+}
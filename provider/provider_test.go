@@ -1,9 +1,137 @@
 package provider
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kislerdm/pulumi-neon/provider/telemetry"
 )
 
 func TestProvider(t *testing.T) {
 	Provider()
 }
+
+// newTestServer returns the provider server configured to send the Neon API requests to the handler.
+func newTestServer(t *testing.T, handler http.Handler) integration.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	s := integration.NewServer(Name, semver.MustParse("0.0.1"), Provider())
+	noRetries := 0.
+	err := s.Configure(p.ConfigureRequest{
+		Args: resource.PropertyMap{
+			"api_key":      resource.NewStringProperty("foo"),
+			"api_endpoint": resource.NewStringProperty(srv.URL),
+			"max_retries":  resource.NewNumberProperty(noRetries),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+// newTestURN returns the URN of the resource of the given type.
+func newTestURN(typ string) resource.URN {
+	return resource.NewURN("test", "test", "", tokens.Type("neon:resource:"+typ), "test")
+}
+
+func Test_parseCompositeID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		n       int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid ID",
+			id:   newCompositeID("foo", "br-bar", "baz"),
+			n:    3,
+			want: []string{"foo", "br-bar", "baz"},
+		},
+		{
+			name:    "too few elements",
+			id:      "foo/br-bar",
+			n:       3,
+			wantErr: true,
+		},
+		{
+			name:    "empty element",
+			id:      "foo//baz",
+			n:       3,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCompositeID(tt.id, tt.n)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_setRetryPolicy(t *testing.T) {
+	noRetries, negativeRetries := 0, -1
+
+	tests := []struct {
+		name           string
+		cfg            Config
+		wantMaxRetries int
+		wantMaxBackoff time.Duration
+		wantErr        bool
+	}{
+		{
+			name:           "default policy",
+			cfg:            Config{},
+			wantMaxRetries: telemetry.DefaultMaxRetries,
+			wantMaxBackoff: telemetry.DefaultMaxBackoff,
+		},
+		{
+			name:           "retries disabled",
+			cfg:            Config{MaxRetries: &noRetries, MaxBackoff: "1m"},
+			wantMaxRetries: 0,
+			wantMaxBackoff: time.Minute,
+		},
+		{
+			name:    "negative max retries",
+			cfg:     Config{MaxRetries: &negativeRetries},
+			wantErr: true,
+		},
+		{
+			name:    "invalid max backoff",
+			cfg:     Config{MaxBackoff: "30"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := telemetry.NewHTTPClient("foo", "0.0.1")
+			err := setRetryPolicy(c, &tt.cfg)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantMaxRetries, c.MaxRetries)
+				assert.Equal(t, tt.wantMaxBackoff, c.MaxBackoff)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2024, Dmitry Kisler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+type Role struct{}
+
+type RoleArgs struct {
+	ProjectID               string  `pulumi:"project_id"`
+	BranchID                string  `pulumi:"branch_id"`
+	Name                    string  `pulumi:"name"`
+	PasswordRotationTrigger *string `pulumi:"password_rotation_trigger,optional"`
+}
+
+func (r *RoleArgs) Annotate(a infer.Annotator) {
+	a.Describe(&r.ProjectID, "Neon project ID.")
+	a.Describe(&r.BranchID, "ID of the branch the role belongs to.")
+	a.Describe(&r.Name, "Role name. It cannot exceed 63 bytes in length.")
+	a.Describe(&r.PasswordRotationTrigger,
+		"Arbitrary value which triggers the password reset when changed. The role is not re-created.")
+}
+
+type RoleState struct {
+	RoleArgs
+	ID        string `pulumi:"identifier"`
+	Password  string `pulumi:"password" provider:"secret"`
+	Protected bool   `pulumi:"protected"`
+}
+
+func (r *RoleState) Annotate(a infer.Annotator) {
+	a.Describe(&r.ID, "Role identifier in the format project_id/branch_id/name.")
+	a.Describe(&r.Password, "Role's password. It is empty if the project does not store the passwords.")
+	a.Describe(&r.Protected, "Whether the role is system-protected.")
+}
+
+func (r Role) Create(ctx context.Context, _ string, inputs RoleArgs, preview bool) (
+	id string, output RoleState, err error) {
+	c, err := NewSDKClient(ctx)
+
+	if !preview && err == nil {
+		var resp sdk.RoleOperations
+		resp, err = c.CreateProjectBranchRole(inputs.ProjectID, inputs.BranchID, sdk.RoleCreateRequest{
+			Role: sdk.RoleCreateRequestRole{Name: inputs.Name},
+		})
+		if err != nil {
+			return id, output, err
+		}
+
+		output = newRoleState(inputs, resp.RoleResponse.Role)
+		id = output.ID
+		err = newResourceInitFailedError(waitForOperations(ctx, c, resp.Operations))
+	}
+
+	return id, output, err
+}
+
+func newRoleState(inputs RoleArgs, role sdk.Role) RoleState {
+	o := RoleState{
+		RoleArgs: inputs,
+		ID:       newCompositeID(inputs.ProjectID, role.BranchID, role.Name),
+	}
+
+	o.BranchID = role.BranchID
+	o.Name = role.Name
+
+	if role.Password != nil {
+		o.Password = *role.Password
+	}
+
+	if role.Protected != nil {
+		o.Protected = *role.Protected
+	}
+
+	return o
+}
+
+func (r Role) Update(ctx context.Context, id string, olds RoleState, news RoleArgs, preview bool) (
+	output RoleState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output = olds
+	output.RoleArgs = news
+	if !preview && optionalChanged(olds.PasswordRotationTrigger, news.PasswordRotationTrigger) {
+		var resp sdk.RoleOperations
+		resp, err = c.ResetProjectBranchRolePassword(news.ProjectID, news.BranchID, news.Name)
+		if err == nil {
+			output = newRoleState(news, resp.RoleResponse.Role)
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+
+	return output, err
+}
+
+func (r Role) Read(ctx context.Context, id string, inputs RoleArgs, state RoleState) (
+	canonicalID string, normalizedInputs RoleArgs, normalizedState RoleState, err error) {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var ids []string
+		ids, err = parseCompositeID(id, 3)
+		if err != nil {
+			return "", inputs, state, err
+		}
+		projectID, branchID, name := ids[0], ids[1], ids[2]
+
+		var resp sdk.RoleResponse
+		resp, err = c.GetProjectBranchRole(projectID, branchID, name)
+		switch {
+		case isNotFound(err):
+			// the role was deleted outside of pulumi
+			return "", inputs, state, nil
+		case err != nil:
+			return "", inputs, state, err
+		}
+
+		args := state.RoleArgs
+		args.ProjectID = projectID
+		normalizedState = newRoleState(args, resp.Role)
+
+		normalizedState.Password, err = readRolePassword(c, projectID, branchID, name)
+		if err != nil {
+			return "", inputs, state, err
+		}
+
+		canonicalID = normalizedState.ID
+		normalizedInputs = normalizedState.RoleArgs
+	}
+
+	return canonicalID, normalizedInputs, normalizedState, err
+}
+
+// readRolePassword reveals the role's password.
+// The password is empty if the project does not store the passwords, hence it cannot be revealed.
+func readRolePassword(c *sdk.Client, projectID, branchID, name string) (string, error) {
+	resp, err := c.GetProjectBranchRolePassword(projectID, branchID, name)
+	if err == nil {
+		return resp.Password, nil
+	}
+
+	respProject, errProject := c.GetProject(projectID)
+	if errProject == nil && !respProject.Project.StorePasswords {
+		return "", nil
+	}
+	return "", err
+}
+
+func (r Role) Delete(ctx context.Context, _ string, props RoleState) error {
+	c, err := NewSDKClient(ctx)
+	if err == nil {
+		var resp sdk.RoleOperations
+		resp, err = c.DeleteProjectBranchRole(props.ProjectID, props.BranchID, props.Name)
+		switch {
+		case isNotFound(err):
+			err = nil
+		case err == nil:
+			err = waitForOperations(ctx, c, resp.Operations)
+		}
+	}
+	return err
+}
+
+func (r Role) Diff(_ context.Context, _ string, olds RoleState, news RoleArgs) (p.DiffResponse, error) {
+	return roleInputChange(olds.RoleArgs, news), nil
+}
+
+func roleInputChange(olds RoleArgs, news RoleArgs) p.DiffResponse {
+	var o = p.DiffResponse{
+		DeleteBeforeReplace: false,
+		HasChanges:          false,
+		DetailedDiff:        make(map[string]p.PropertyDiff),
+	}
+
+	// the role cannot be renamed, or moved
+	for k, changed := range map[string]bool{
+		"project_id": news.ProjectID != olds.ProjectID,
+		"branch_id":  news.BranchID != olds.BranchID,
+		"name":       news.Name != olds.Name,
+	} {
+		if changed {
+			o.HasChanges = true
+			o.DeleteBeforeReplace = true
+			o.DetailedDiff[k] = p.PropertyDiff{
+				Kind:      p.UpdateReplace,
+				InputDiff: true,
+			}
+		}
+	}
+
+	// the password is reset in place
+	if optionalChanged(olds.PasswordRotationTrigger, news.PasswordRotationTrigger) {
+		o.HasChanges = true
+		o.DetailedDiff["password_rotation_trigger"] = p.PropertyDiff{
+			Kind:      p.Update,
+			InputDiff: true,
+		}
+	}
+
+	return o
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/kislerdm/neon-sdk-go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_roleInputChange(t *testing.T) {
+	triggerFoo, triggerBar := "foo", "bar"
+
+	tests := []struct {
+		name string
+		olds RoleArgs
+		news RoleArgs
+		want p.DiffResponse
+	}{
+		{
+			name: "no changes",
+			olds: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo", PasswordRotationTrigger: &triggerFoo},
+			news: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo", PasswordRotationTrigger: &triggerFoo},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "password rotation trigger removed from the manifest",
+			olds: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo", PasswordRotationTrigger: &triggerFoo},
+			news: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo"},
+			want: p.DiffResponse{DetailedDiff: map[string]p.PropertyDiff{}},
+		},
+		{
+			name: "password reset in place",
+			olds: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo", PasswordRotationTrigger: &triggerFoo},
+			news: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo", PasswordRotationTrigger: &triggerBar},
+			want: p.DiffResponse{
+				HasChanges: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"password_rotation_trigger": {Kind: p.Update, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "role renamed",
+			olds: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo"},
+			news: RoleArgs{ProjectID: "p", BranchID: "br", Name: "bar"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"name": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+		{
+			name: "branch changed",
+			olds: RoleArgs{ProjectID: "p", BranchID: "br", Name: "foo"},
+			news: RoleArgs{ProjectID: "p", BranchID: "br-new", Name: "foo"},
+			want: p.DiffResponse{
+				HasChanges:          true,
+				DeleteBeforeReplace: true,
+				DetailedDiff: map[string]p.PropertyDiff{
+					"branch_id": {Kind: p.UpdateReplace, InputDiff: true},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, roleInputChange(tt.olds, tt.news))
+		})
+	}
+}
+
+func Test_readRolePassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		storePasswords bool
+		revealStatus   int
+		want           string
+		wantErr        bool
+	}{
+		{
+			name:           "password revealed",
+			storePasswords: true,
+			revealStatus:   http.StatusOK,
+			want:           "qux",
+		},
+		{
+			name:           "password not stored",
+			storePasswords: false,
+			revealStatus:   http.StatusPreconditionFailed,
+			want:           "",
+		},
+		{
+			name:           "password stored, but could not be revealed",
+			storePasswords: true,
+			revealStatus:   http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/projects/foo/branches/br-foo/roles/bar/reveal_password":
+					w.WriteHeader(tt.revealStatus)
+					if tt.revealStatus == http.StatusOK {
+						_, _ = w.Write([]byte(`{"password":"qux"}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"code":"","message":"could not reveal the password"}`))
+				case "/projects/foo":
+					if tt.storePasswords {
+						_, _ = w.Write([]byte(`{"project":{"id":"foo","store_passwords":true}}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"project":{"id":"foo","store_passwords":false}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"code":"","message":"not found"}`))
+				}
+			}))
+			defer srv.Close()
+
+			c, err := sdk.NewClient(sdk.Config{
+				Key:        "key",
+				HTTPClient: endpointHTTPClient{endpoint: srv.URL, c: srv.Client()},
+			})
+			assert.NoError(t, err)
+
+			got, err := readRolePassword(c, "foo", "br-foo", "bar")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
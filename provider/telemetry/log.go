@@ -0,0 +1,150 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// requestIDHeaders the response headers which carry the ID of the request processed by the Neon API.
+var requestIDHeaders = []string{"X-Neon-Ret-Request-Id", "X-Request-Id"}
+
+// secretFields the JSON fields with the secret values, e.g. the role's password, the connection URI, or the API key.
+var secretFields = []string{"password", "connection_uri", "uri", "key"}
+
+// logResponse logs the Neon API call at the debug level.
+// The headers and the bodies are logged only if Verbose is set, the secrets are redacted.
+func (c HTTPClient) logResponse(r *http.Request, resp *http.Response, latency time.Duration) {
+	if c.Logger == nil {
+		return
+	}
+
+	msg := fmt.Sprintf("neon api call: method=%s path=%s status=%d latency=%s request_id=%s",
+		r.Method, r.URL.Path, resp.StatusCode, latency.Round(time.Millisecond), requestID(resp.Header))
+
+	if c.Verbose {
+		var respBody []byte
+		if resp.Body != nil {
+			respBody, _ = io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		}
+
+		msg += fmt.Sprintf(" request_headers=%s request_body=%s response_body=%s",
+			redactHeaders(r.Header), redactBody(readRequestBody(r)), redactBody(respBody))
+	}
+
+	c.Logger.Debugf("%s", msg)
+}
+
+// logError logs the Neon API call which did not receive the response at the debug level.
+// The headers and the body are logged only if Verbose is set, the secrets are redacted.
+func (c HTTPClient) logError(r *http.Request, err error, latency time.Duration) {
+	if c.Logger == nil {
+		return
+	}
+
+	msg := fmt.Sprintf("neon api call: method=%s path=%s latency=%s error=%q",
+		r.Method, r.URL.Path, latency.Round(time.Millisecond), err.Error())
+
+	if c.Verbose {
+		msg += fmt.Sprintf(" request_headers=%s request_body=%s",
+			redactHeaders(r.Header), redactBody(readRequestBody(r)))
+	}
+
+	c.Logger.Debugf("%s", msg)
+}
+
+func requestID(h http.Header) string {
+	for _, k := range requestIDHeaders {
+		if v := h.Get(k); v != "" {
+			return v
+		}
+	}
+	return "unknown"
+}
+
+// readRequestBody reads the copy of the request body, so the request can still be sent.
+func readRequestBody(r *http.Request) []byte {
+	if r.GetBody == nil {
+		return nil
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = body.Close() }()
+
+	b, _ := io.ReadAll(body)
+	return b
+}
+
+func redactHeaders(h http.Header) string {
+	h = h.Clone()
+	if h.Get("Authorization") != "" {
+		h.Set("Authorization", redacted)
+	}
+
+	var o = make([]string, 0, len(h))
+	for k, v := range h {
+		o = append(o, k+": "+strings.Join(v, ","))
+	}
+	slices.Sort(o)
+	return "{" + strings.Join(o, "; ") + "}"
+}
+
+// redactBody redacts the values of the secret JSON fields, and the Postgres connection URIs set in any field.
+// The body which is not a valid JSON is not logged, because its secrets cannot be found reliably.
+func redactBody(b []byte) string {
+	if len(b) == 0 {
+		return "<empty>"
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return fmt.Sprintf("<non-JSON body of %d bytes>", len(b))
+	}
+
+	o, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<non-JSON body of %d bytes>", len(b))
+	}
+	return string(o)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, el := range v {
+			if _, ok := el.(string); ok && slices.Contains(secretFields, k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(el)
+		}
+		return v
+	case []any:
+		for i, el := range v {
+			v[i] = redactValue(el)
+		}
+		return v
+	case string:
+		if strings.HasPrefix(v, "postgres://") || strings.HasPrefix(v, "postgresql://") {
+			return redacted
+		}
+		return v
+	default:
+		return v
+	}
+}
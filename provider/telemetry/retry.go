@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries the default maximum number of retries of the failed request.
+	DefaultMaxRetries = 5
+	// DefaultMaxBackoff the default maximum duration to wait before the request is retried.
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultMaxRetryAfter the default maximum duration requested by the server using the Retry-After header
+	// to wait before the request is retried.
+	DefaultMaxRetryAfter = 5 * time.Minute
+
+	baseBackoff = time.Second
+)
+
+// isRetryable checks if the request can be retried safely.
+// The Neon API rejects the request without processing it if the project is locked by a running operation (423),
+// or if the rate limit is exceeded (429), hence any request can be retried in this case.
+// The requests which failed because of the server errors (5xx) are retried only if they are idempotent.
+func isRetryable(r *http.Request, statusCode int) bool {
+	// the request's body cannot be sent again
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false
+	}
+
+	switch {
+	case statusCode == http.StatusLocked, statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode >= http.StatusInternalServerError:
+		return isIdempotent(r.Method)
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff defines the duration to wait before the request is retried.
+// The exponential backoff with jitter does not exceed MaxBackoff, the duration requested by the server
+// using the Retry-After header is the minimum wait, so the request is not retried while the project is locked.
+// The request is not retried if the server requests to wait longer than MaxRetryAfter.
+func (c HTTPClient) backoff(attempt int, retryAfter string) (time.Duration, bool) {
+	d := c.MaxBackoff
+	if attempt < 32 {
+		d = min(baseBackoff<<attempt, c.MaxBackoff)
+	}
+
+	// the jitter prevents the retries of concurrent requests from being sent simultaneously
+	wait := d/2 + rand.N(d/2+1)
+
+	if v, ok := parseRetryAfter(retryAfter, time.Now()); ok {
+		if v > c.MaxRetryAfter {
+			return v, false
+		}
+		wait = max(wait, v)
+	}
+	return wait, true
+}
+
+// exceedsDeadline checks if the request cannot be retried after the wait because the context's deadline passes.
+func exceedsDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Now().Add(wait).After(deadline)
+}
+
+// parseRetryAfter parses the value of the Retry-After header set as the number of seconds, or as the HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,239 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type loggerMock struct {
+	messages      []string
+	debugMessages []string
+}
+
+func (l *loggerMock) Infof(msg string, a ...any) {
+	l.messages = append(l.messages, fmt.Sprintf(msg, a...))
+}
+
+func (l *loggerMock) Debugf(msg string, a ...any) {
+	l.debugMessages = append(l.debugMessages, fmt.Sprintf(msg, a...))
+}
+
+func TestHTTPClient_Do_retry(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		statusCodes []int
+		maxRetries  int
+		wantStatus  int
+		wantCalls   int
+	}{
+		{
+			name:        "project locked by the running operation",
+			method:      http.MethodPost,
+			statusCodes: []int{http.StatusLocked, http.StatusLocked, http.StatusCreated},
+			maxRetries:  3,
+			wantStatus:  http.StatusCreated,
+			wantCalls:   3,
+		},
+		{
+			name:        "rate limit exceeded, retries exhausted",
+			method:      http.MethodPatch,
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			maxRetries:  2,
+			wantStatus:  http.StatusTooManyRequests,
+			wantCalls:   3,
+		},
+		{
+			name:        "server error of the idempotent request",
+			method:      http.MethodDelete,
+			statusCodes: []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries:  3,
+			wantStatus:  http.StatusOK,
+			wantCalls:   2,
+		},
+		{
+			name:        "server error of the non-idempotent request",
+			method:      http.MethodPost,
+			statusCodes: []int{http.StatusInternalServerError, http.StatusCreated},
+			maxRetries:  3,
+			wantStatus:  http.StatusInternalServerError,
+			wantCalls:   1,
+		},
+		{
+			name:        "client error",
+			method:      http.MethodGet,
+			statusCodes: []int{http.StatusNotFound, http.StatusOK},
+			maxRetries:  3,
+			wantStatus:  http.StatusNotFound,
+			wantCalls:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				if tt.method == http.MethodPost || tt.method == http.MethodPatch {
+					assert.Equal(t, `{"foo":"bar"}`, string(b))
+				}
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.statusCodes[calls])
+				calls++
+			}))
+			defer srv.Close()
+
+			logger := &loggerMock{}
+			c := NewHTTPClient("foo", "0.0.1")
+			c.MaxRetries = tt.maxRetries
+			c.MaxBackoff = 10 * time.Millisecond
+			c.Logger = logger
+
+			req, err := http.NewRequest(tt.method, srv.URL+"/projects", bytes.NewReader([]byte(`{"foo":"bar"}`)))
+			assert.NoError(t, err)
+
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Len(t, logger.messages, tt.wantCalls-1)
+			assert.Len(t, logger.debugMessages, tt.wantCalls)
+		})
+	}
+}
+
+func TestHTTPClient_backoff(t *testing.T) {
+	c := HTTPClient{MaxBackoff: 10 * time.Second, MaxRetryAfter: 2 * time.Minute}
+
+	t.Run("exponential backoff with jitter", func(t *testing.T) {
+		for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+			got, ok := c.backoff(attempt, "")
+			assert.True(t, ok)
+			assert.GreaterOrEqual(t, got, want/2)
+			assert.LessOrEqual(t, got, want)
+		}
+	})
+
+	t.Run("backoff limited by max backoff", func(t *testing.T) {
+		got, ok := c.backoff(100, "")
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, got, c.MaxBackoff/2)
+		assert.LessOrEqual(t, got, c.MaxBackoff)
+	})
+
+	t.Run("Retry-After is the minimum wait", func(t *testing.T) {
+		got, ok := c.backoff(0, "3")
+		assert.True(t, ok)
+		assert.Equal(t, 3*time.Second, got)
+
+		got, ok = c.backoff(0, "120")
+		assert.True(t, ok)
+		assert.Equal(t, 120*time.Second, got)
+
+		got, ok = c.backoff(3, "0")
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, got, 4*time.Second)
+		assert.LessOrEqual(t, got, 8*time.Second)
+	})
+
+	t.Run("Retry-After exceeds max Retry-After", func(t *testing.T) {
+		got, ok := c.backoff(0, "121")
+		assert.False(t, ok)
+		assert.Equal(t, 121*time.Second, got)
+	})
+}
+
+func TestHTTPClient_Do_retryAfterLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryAfter    string
+		maxRetryAfter time.Duration
+		timeout       time.Duration
+	}{
+		{
+			name:          "Retry-After exceeds max Retry-After",
+			retryAfter:    "3600",
+			maxRetryAfter: DefaultMaxRetryAfter,
+		},
+		{
+			name:          "Retry-After exceeds the deadline",
+			retryAfter:    "60",
+			maxRetryAfter: DefaultMaxRetryAfter,
+			timeout:       time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", tt.retryAfter)
+				w.WriteHeader(http.StatusTooManyRequests)
+				calls++
+			}))
+			defer srv.Close()
+
+			logger := &loggerMock{}
+			c := NewHTTPClient("foo", "0.0.1")
+			c.MaxRetryAfter = tt.maxRetryAfter
+			c.Logger = logger
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/projects", nil)
+			assert.NoError(t, err)
+
+			start := time.Now()
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			_ = resp.Body.Close()
+
+			// the response is returned right away
+			assert.Less(t, time.Since(start), time.Second)
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Equal(t, 1, calls)
+			assert.Len(t, logger.messages, 1)
+			assert.Contains(t, logger.messages[0], "not retrying")
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		v      string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "not set", v: "", want: 0, wantOK: false},
+		{name: "seconds", v: "5", want: 5 * time.Second, wantOK: true},
+		{name: "HTTP date", v: "Fri, 01 Nov 2024 00:00:10 GMT", want: 10 * time.Second, wantOK: true},
+		{name: "HTTP date in the past", v: "Thu, 31 Oct 2024 00:00:00 GMT", want: 0, wantOK: true},
+		{name: "invalid value", v: "foo", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.v, now)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
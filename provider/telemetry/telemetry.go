@@ -2,7 +2,11 @@
 package telemetry
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"time"
 )
@@ -12,6 +16,9 @@ func NewHTTPClient(providerName, providerVersion string) *HTTPClient {
 	return &HTTPClient{
 		ProviderName:    providerName,
 		ProviderVersion: providerVersion,
+		MaxRetries:      DefaultMaxRetries,
+		MaxBackoff:      DefaultMaxBackoff,
+		MaxRetryAfter:   DefaultMaxRetryAfter,
 		c:               &http.Client{Timeout: 2 * time.Minute},
 	}
 }
@@ -21,13 +28,104 @@ type HTTPClient struct {
 	ProviderName    string
 	ProviderVersion string
 
-	c *http.Client
+	// MaxRetries the maximum number of retries of the failed request.
+	MaxRetries int
+	// MaxBackoff the maximum duration of the exponential backoff, the Retry-After set by the server is not capped by it.
+	MaxBackoff time.Duration
+	// MaxRetryAfter the maximum duration requested by the server using the Retry-After header to wait for,
+	// the response is returned without retries if the server requests to wait longer.
+	MaxRetryAfter time.Duration
+	// Logger the logger to report the retries, and the API calls at the debug level.
+	Logger Logger
+	// Verbose enables logging the headers and the bodies of the API calls, the secrets are redacted.
+	Verbose bool
+
+	c   *http.Client
+	ctx context.Context
+}
+
+// WithContext binds the context to the requests sent without it, e.g. by the Neon SDK,
+// so the requests are cancelled together with the context.
+func (c HTTPClient) WithContext(ctx context.Context) *HTTPClient {
+	c.ctx = ctx
+	return &c
+}
+
+// Logger logs the messages visible to the user.
+type Logger interface {
+	Infof(msg string, a ...any)
+	Debugf(msg string, a ...any)
 }
 
 func (c HTTPClient) Do(r *http.Request) (*http.Response, error) {
+	if c.ctx != nil && r.Context() == context.Background() {
+		r = r.WithContext(c.ctx)
+	}
+
 	c.setUAHeader(r)
 
-	return c.c.Do(r)
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := c.c.Do(r)
+		if err != nil {
+			c.logError(r, err, time.Since(start))
+			return nil, wrapError(r, err)
+		}
+		c.logResponse(r, resp, time.Since(start))
+
+		if attempt >= c.MaxRetries || !isRetryable(r, resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait, ok := c.backoff(attempt, resp.Header.Get("Retry-After"))
+		if !ok || exceedsDeadline(r.Context(), wait) {
+			// the response is returned right away instead of failing with the timeout after the wait
+			if c.Logger != nil {
+				c.Logger.Infof("%s %s responded with %s, not retrying: the wait of %s exceeds the limit, "+
+					"or the operation's deadline", r.Method, r.URL.Path, resp.Status, wait.Round(time.Millisecond))
+			}
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if r.GetBody != nil {
+			if r.Body, err = r.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.Logger != nil {
+			c.Logger.Infof("%s %s responded with %s, retrying in %s (retry %d of %d)",
+				r.Method, r.URL.Path, resp.Status, wait.Round(time.Millisecond), attempt+1, c.MaxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, wrapError(r, r.Context().Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// wrapError explains why the request was interrupted.
+func wrapError(r *http.Request, err error) error {
+	switch ctxErr := r.Context().Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		return fmt.Errorf("the request timed out, consider increasing the resource's customTimeouts: %w", err)
+	case errors.Is(ctxErr, context.Canceled):
+		return fmt.Errorf("the request was cancelled: %w", err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("the request timed out, the Neon API did not respond in time: %w", err)
+	}
+
+	return err
 }
 
 func (c HTTPClient) setUAHeader(r *http.Request) {
@@ -1,9 +1,12 @@
 package telemetry
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -47,3 +50,48 @@ func TestHTTPClient_setUAHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPClient_Do_context(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		c := NewHTTPClient("Foo", "1.0.0").WithContext(ctx)
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		assert.NoError(t, err)
+
+		_, err = c.Do(req)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "the request was cancelled")
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		c := NewHTTPClient("Foo", "1.0.0").WithContext(ctx)
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		assert.NoError(t, err)
+
+		_, err = c.Do(req)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "consider increasing the resource's customTimeouts")
+	})
+
+	t.Run("request context takes precedence", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		c := NewHTTPClient("Foo", "1.0.0").WithContext(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		assert.NoError(t, err)
+
+		_, err = c.Do(req)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}